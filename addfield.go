@@ -0,0 +1,43 @@
+package loggy
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+const fieldsctxkey = logContextKey("fields")
+
+// contextField is a field added with AddField. Each one links to the fields
+// added before it, so a context never observes fields added to its children.
+type contextField struct {
+	parent *contextField
+	field  interface{}
+	n      int
+}
+
+// AddField returns a copy of ctx carrying the field key with value, which is
+// attached to every entry logged with the returned context, in addition to the
+// fields of its logger. Unlike With, it does not create a child logger, so it is
+// cheap enough to call for every field added in a loop.
+func AddField(ctx context.Context, key string, value interface{}) context.Context {
+	parent, _ := ctx.Value(fieldsctxkey).(*contextField)
+	f := &contextField{parent: parent, field: zap.Any(key, value), n: 1}
+	if parent != nil {
+		f.n += parent.n
+	}
+	return context.WithValue(ctx, fieldsctxkey, f)
+}
+
+// contextFields returns the fields added to ctx with AddField, in the order they were added.
+func contextFields(ctx context.Context) []interface{} {
+	f, _ := ctx.Value(fieldsctxkey).(*contextField)
+	if f == nil {
+		return nil
+	}
+	fields := make([]interface{}, f.n)
+	for ; f != nil; f = f.parent {
+		fields[f.n-1] = f.field
+	}
+	return fields
+}
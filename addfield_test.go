@@ -0,0 +1,67 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAddField(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	first := AddField(ctx, "step", 1)
+	second := AddField(first, "attempt", 2)
+	sibling := AddField(first, "retry", true)
+
+	l.Info(ctx, "something goes here")
+	l.Info(first, "something goes here")
+	l.Infow(second, "something goes here", "key", "value")
+	l.Info(sibling, "something goes here")
+
+	require.Equal(t,
+		`{"level":"info","msg":"something goes here","request_id":"<request-id-value>"}`+"\n"+
+			`{"level":"info","msg":"something goes here","request_id":"<request-id-value>","step":1}`+"\n"+
+			`{"level":"info","msg":"something goes here","request_id":"<request-id-value>","step":1,"attempt":2,"key":"value"}`+"\n"+
+			`{"level":"info","msg":"something goes here","request_id":"<request-id-value>","step":1,"retry":true}`+"\n",
+		buf.String(),
+	)
+}
+
+func BenchmarkLoggy_AddField(b *testing.B) {
+	l := New(zap.NewNop().Sugar())
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		fieldCtx := ctx
+		for j := 0; j < 10; j++ {
+			fieldCtx = AddField(fieldCtx, "key"+strconv.Itoa(j), j)
+		}
+		l.Info(fieldCtx, "something goes here")
+	}
+}
+
+func BenchmarkLoggy_With(b *testing.B) {
+	l := New(zap.NewNop().Sugar())
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		fieldCtx := ctx
+		for j := 0; j < 10; j++ {
+			fieldCtx, _ = l.With(fieldCtx, "key"+strconv.Itoa(j), j)
+		}
+		l.Info(fieldCtx, "something goes here")
+	}
+}
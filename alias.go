@@ -0,0 +1,59 @@
+package loggy
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithFieldAliases rewrites the keys of fields to a canonical key before they are
+// encoded. aliases maps each alias to its canonical key, for example
+// {"userId": "user_id", "uid": "user_id"}. When an entry carries both an alias and
+// its canonical key, the canonical field is kept, the alias is dropped and a
+// warning is logged.
+func WithFieldAliases(aliases map[string]string) Option {
+	return func(o *options) {
+		o.fieldAliases = make(map[string]string, len(aliases))
+		for alias, canonical := range aliases {
+			o.fieldAliases[alias] = canonical
+		}
+	}
+}
+
+func (c *core) alias(o *options, ent zapcore.Entry, fields []zapcore.Field) []zapcore.Field {
+	if len(o.fieldAliases) == 0 {
+		return fields
+	}
+
+	var namespace int
+	present := make(map[fieldKey]struct{}, len(fields))
+	for _, f := range fields {
+		if f.Type == zapcore.NamespaceType {
+			namespace++
+			continue
+		}
+		present[fieldKey{namespace: namespace, key: f.Key}] = struct{}{}
+	}
+
+	namespace = 0
+	out := fields[:0]
+	for _, f := range fields {
+		if f.Type == zapcore.NamespaceType {
+			namespace++
+			out = append(out, f)
+			continue
+		}
+		canonical, ok := o.fieldAliases[f.Key]
+		if !ok {
+			out = append(out, f)
+			continue
+		}
+		if _, conflict := present[fieldKey{namespace: namespace, key: canonical}]; conflict {
+			c.root.stats.addDropped(1)
+			c.warn(ent, "loggy: dropped field alias in favor of its canonical key", zap.String("alias", f.Key), zap.String("key", canonical))
+			continue
+		}
+		f.Key = canonical
+		out = append(out, f)
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithFieldAliases(t *testing.T) {
+	tests := map[string]struct {
+		args []interface{}
+		want string
+	}{
+		"Should rewrite an alias to its canonical key": {
+			args: []interface{}{"userId", 5},
+			want: `{"level":"info","msg":"something goes here","request_id":"<request-id-value>","user_id":5}` + "\n",
+		},
+		"Should prefer the canonical key over an alias and warn": {
+			args: []interface{}{"uid", 6, "user_id", 5},
+			want: `{"level":"warn","msg":"loggy: dropped field alias in favor of its canonical key","alias":"uid","key":"user_id"}` + "\n" +
+				`{"level":"info","msg":"something goes here","request_id":"<request-id-value>","user_id":5}` + "\n",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithFieldAliases(map[string]string{
+				"userId": "user_id",
+				"uid":    "user_id",
+			}))
+
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+			l.Infow(ctx, "something goes here", tc.args...)
+
+			require.Equal(t, tc.want, buf.String())
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package loggy
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithAuditSink writes the entries logged with Audit to ws, encoded with enc,
+// instead of alongside every other entry, so they can be retained separately.
+func WithAuditSink(ws zapcore.WriteSyncer, enc zapcore.Encoder) Option {
+	return func(o *options) {
+		o.auditCore = zapcore.NewCore(enc, ws, zapcore.DebugLevel)
+	}
+}
+
+// Audit logs action as the message of an audit entry, with the audit field set
+// to true, the key/value pairs in args, and the fields of the logger carried by
+// ctx, such as its correlation ID. Audit entries are never dropped: they are
+// written at InfoLevel regardless of the levels enabled, and bypass sampling,
+// rate limits, entry caps, summaries and buffering. Redaction still applies.
+// They are written to the sink set with WithAuditSink, if any. The action is kept
+// as is, so it is not checked by WithMessageValidator. Audit does nothing on a
+// zero Logger, unless ctx carries a logger.
+func (l Logger) Audit(ctx context.Context, action string, args ...interface{}) {
+	logger := l.extractLogger(ctx)
+	if logger.root == nil {
+		return
+	}
+	o := logger.root.options()
+	s := withCore(l.sugar(ctx), func(c *core) {
+		if o.auditCore != nil {
+			c.base = o.auditCore
+		}
+		c.audit = true
+		c.summary = nil
+		c.pending = nil
+		c.escalate = false
+	})
+	fields := make([]interface{}, 0, len(args)+1)
+	fields = append(fields, args...)
+	s.Infow(action, append(fields, zap.Bool("audit", true))...)
+}
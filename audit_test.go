@@ -0,0 +1,75 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_Audit(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	auditBuf := bytes.NewBuffer([]byte{})
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: zapcore.LowercaseLevelEncoder})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(),
+		WithLevel(zapcore.ErrorLevel),
+		WithSampling(0, 0, 0),
+		WithRedactedKeys("password"),
+		WithAuditSink(zapcore.AddSync(auditBuf), enc),
+	)
+
+	ctx, _ := l.With(context.Background(), "correlation_id", "<correlation-id-value>")
+	l.Info(ctx, "dropped")
+	l.Audit(ctx, "user.deleted", "user_id", 7, "password", "<password-value>")
+	l.Audit(ctx, "user.deleted", "user_id", 8)
+
+	require.Empty(t, buf.String())
+	require.Equal(t,
+		`{"level":"info","msg":"user.deleted","correlation_id":"<correlation-id-value>","user_id":7,"password":"[REDACTED]","audit":true}`+"\n"+
+			`{"level":"info","msg":"user.deleted","correlation_id":"<correlation-id-value>","user_id":8,"audit":true}`+"\n",
+		auditBuf.String(),
+	)
+}
+
+func TestLogger_Audit_NoSink(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithLevel(zapcore.ErrorLevel))
+
+	ctx := l.BufferedContext(context.Background())
+	l.Audit(ctx, "user.created")
+
+	require.Equal(t, `{"level":"info","msg":"user.created","audit":true}`+"\n", buf.String())
+}
+
+func TestLogger_Audit_SkipsMessageValidator(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithMessageValidator(func(msg string) error {
+		return errors.New("unapproved")
+	}))
+
+	l.Audit(context.Background(), "user.deleted")
+
+	require.Equal(t, `{"level":"info","msg":"user.deleted","audit":true}`+"\n", buf.String())
+}
+
+func TestLogger_Audit_ZeroLogger(t *testing.T) {
+	require.NotPanics(t, func() {
+		Logger{}.Audit(context.Background(), "user.deleted")
+	})
+}
+
+func TestLogger_Audit_LeavesArgsUntouched(t *testing.T) {
+	l := New(newZapTestLogger(t, zapcore.AddSync(bytes.NewBuffer([]byte{}))).Sugar())
+
+	args := make([]interface{}, 2, 3)
+	args[0], args[1] = "user_id", 7
+	spare := args[:3]
+	spare[2] = "<spare-value>"
+
+	l.Audit(context.Background(), "user.deleted", args...)
+
+	require.Equal(t, "<spare-value>", spare[2])
+}
@@ -0,0 +1,110 @@
+package loggy
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Batch collects entries logged through it so they can be written contiguously.
+// It is only valid within the function passed to Logger.Batch.
+type Batch struct {
+	s *zap.SugaredLogger
+}
+
+// Batch calls fn with a Batch that collects the entries logged through it, using
+// the logger carried by ctx. Once fn returns, the entries are written in order
+// under a single lock shared with every logger derived from the same root, so no
+// other entry is interleaved with them, including those of its clones. This keeps
+// related entries together in human readable output. Write errors go to the write
+// error handler, if there is one, and are otherwise dropped.
+func (l Logger) Batch(ctx context.Context, fn func(b *Batch)) {
+	pending := &pendingEntries{}
+	fn(&Batch{s: withCore(l.sugar(ctx), func(c *core) {
+		c.pending = pending
+	})})
+	r := l.extractLogger(ctx).root
+	_ = handleWriteError(r.options(), pending.flush(r))
+}
+
+// Debugw adds a message with some additional context at DebugLevel to the batch.
+func (b *Batch) Debugw(msg string, keysAndValues ...interface{}) {
+	b.s.Debugw(msg, keysAndValues...)
+}
+
+// Infow adds a message with some additional context at InfoLevel to the batch.
+func (b *Batch) Infow(msg string, keysAndValues ...interface{}) {
+	b.s.Infow(msg, keysAndValues...)
+}
+
+// Warnw adds a message with some additional context at WarnLevel to the batch.
+func (b *Batch) Warnw(msg string, keysAndValues ...interface{}) {
+	b.s.Warnw(msg, keysAndValues...)
+}
+
+// Errorw adds a message with some additional context at ErrorLevel to the batch.
+func (b *Batch) Errorw(msg string, keysAndValues ...interface{}) {
+	b.s.Errorw(msg, keysAndValues...)
+}
+
+// pendingEntries are entries held until they are written. Their fields are
+// processed when they are logged, so lazy values and the state they depend on
+// are captured then, and only their encoding is deferred.
+type pendingEntries struct {
+	mu      sync.Mutex
+	entries []pendingEntry
+	// closed is set once the entries are flushed for the last time.
+	closed bool
+}
+
+type pendingEntry struct {
+	core   *core
+	ent    zapcore.Entry
+	fields []zapcore.Field
+}
+
+// add holds an entry logged through c, with its processed fields, until the
+// next flush, and reports whether it did. Entries are no longer held once p is
+// closed.
+func (p *pendingEntries) add(c *core, ent zapcore.Entry, fields []zapcore.Field) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return false
+	}
+	p.entries = append(p.entries, pendingEntry{core: c, ent: ent, fields: fields})
+	return true
+}
+
+func (p *pendingEntries) flush(r *root) error {
+	return p.write(r, false)
+}
+
+// close flushes the entries and stops holding new ones.
+func (p *pendingEntries) close(r *root) error {
+	return p.write(r, true)
+}
+
+func (p *pendingEntries) write(r *root, close bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return p.writeLocked(close)
+}
+
+// writeLocked writes the entries held by p. The lock of the root must be held.
+func (p *pendingEntries) writeLocked(close bool) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = p.closed || close
+
+	var err error
+	for _, e := range p.entries {
+		err = multierr.Append(err, e.core.writeBase(e.ent, e.fields))
+	}
+	p.entries = nil
+	return err
+}
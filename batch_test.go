@@ -0,0 +1,115 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+// gatedWriter blocks the write of the first line containing gate until released,
+// signalling when it starts blocking.
+type gatedWriter struct {
+	gate    string
+	blocked chan struct{}
+	release chan struct{}
+
+	mu   sync.Mutex
+	once sync.Once
+	buf  bytes.Buffer
+}
+
+func (w *gatedWriter) Write(p []byte) (int, error) {
+	if strings.Contains(string(p), w.gate) {
+		w.once.Do(func() {
+			close(w.blocked)
+			<-w.release
+		})
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.buf.Write(p)
+}
+
+func (w *gatedWriter) Sync() error {
+	return nil
+}
+
+func TestLogger_Batch(t *testing.T) {
+	tests := map[string]struct {
+		concurrent func(l Logger) Logger
+	}{
+		"Should not interleave the entries of the same logger": {
+			concurrent: func(l Logger) Logger { return l },
+		},
+		"Should not interleave the entries of a clone": {
+			concurrent: func(l Logger) Logger { return l.Clone() },
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			ws := &gatedWriter{gate: "first", blocked: make(chan struct{}), release: make(chan struct{})}
+			l := New(newZapTestLogger(t, ws).Sugar())
+			concurrent := tc.concurrent(l)
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+
+			batchDone := make(chan struct{})
+			go func() {
+				defer close(batchDone)
+				l.Batch(ctx, func(b *Batch) {
+					b.Infow("first", "item", 1)
+					b.Infow("second", "item", 2)
+					b.Warnw("third", "item", 3)
+				})
+			}()
+
+			// The batch is now being flushed and is blocked after its first entry.
+			<-ws.blocked
+
+			concurrentDone := make(chan struct{})
+			go func() {
+				defer close(concurrentDone)
+				concurrent.Info(context.Background(), "concurrent")
+			}()
+
+			select {
+			case <-concurrentDone:
+				t.Fatal("concurrent entry was written while the batch was being flushed")
+			case <-time.After(50 * time.Millisecond):
+			}
+
+			close(ws.release)
+			<-batchDone
+			<-concurrentDone
+
+			require.Equal(t,
+				`{"level":"info","msg":"first","request_id":"<request-id-value>","item":1}`+"\n"+
+					`{"level":"info","msg":"second","request_id":"<request-id-value>","item":2}`+"\n"+
+					`{"level":"warn","msg":"third","request_id":"<request-id-value>","item":3}`+"\n"+
+					`{"level":"info","msg":"concurrent"}`+"\n",
+				ws.buf.String(),
+			)
+		})
+	}
+}
+
+func TestLogger_Batch_WriteErrors(t *testing.T) {
+	var errs []error
+	l := New(newZapTestLogger(t, failingWriteSyncer{}).Sugar(), WithWriteErrorHandler(func(err error) {
+		errs = append(errs, err)
+	}))
+
+	l.Batch(context.Background(), func(b *Batch) {
+		b.Infow("first")
+		b.Infow("second")
+	})
+
+	require.Len(t, errs, 1)
+	require.Len(t, multierr.Errors(errs[0]), 2)
+}
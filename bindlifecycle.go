@@ -0,0 +1,19 @@
+//go:build go1.21
+// +build go1.21
+
+package loggy
+
+import (
+	"context"
+)
+
+// BindLifecycle flushes any buffered entries of l once ctx is done, so that a
+// short-lived program, such as a CLI command, does not lose its last entries to
+// a forgotten Sync. No goroutine waits on ctx in the meantime, and nothing is
+// left behind once the flush ran. Sync errors go to the write error handler, if
+// there is one, and are otherwise dropped. It is only available from Go 1.21.
+func (l Logger) BindLifecycle(ctx context.Context) {
+	context.AfterFunc(ctx, func() {
+		_ = l.s.Sync()
+	})
+}
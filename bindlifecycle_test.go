@@ -0,0 +1,28 @@
+//go:build go1.21
+// +build go1.21
+
+package loggy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_BindLifecycle(t *testing.T) {
+	buf := &lockedBuffer{}
+	l := NewBuffered(buf, 4096, time.Hour, zapcore.DebugLevel, bufferedTestConfig())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l.BindLifecycle(ctx)
+	l.Info(ctx, "something goes here")
+	require.Empty(t, buf.String())
+
+	cancel()
+	require.Eventually(t, func() bool {
+		return buf.String() == `{"level":"info","msg":"something goes here"}`+"\n"
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,77 @@
+package loggy
+
+import (
+	"context"
+
+	"go.uber.org/multierr"
+)
+
+// BufferedContext returns a copy of ctx whose logger holds the entries logged
+// through it, and writes them in order, under the same lock as Batch, once ctx is
+// done or Flush is called. This saves write calls for request-scoped logging, at
+// the cost of the entries only appearing at the end of the request. Entries logged
+// after ctx is done are written immediately. If ctx is never done, the entries are
+// only written by Flush. Reconfigure is the exception: it writes the entries held
+// for the contexts that are not done yet before the reload, so they are written
+// with the options they were logged with. The fields of the entries are processed when they are
+// logged, so lazy values are resolved then, and only their encoding is deferred.
+// Errors writing the entries once ctx is done go to the write error handler, if
+// there is one, and are otherwise dropped.
+func (l Logger) BufferedContext(ctx context.Context) context.Context {
+	l = l.extractLogger(ctx)
+	pending := &pendingEntries{}
+	newLogger := Logger{s: withCore(l.s, func(c *core) { c.pending = pending }), root: l.root}
+
+	if done := ctx.Done(); done != nil {
+		l.root.addBuffer(pending)
+		go func() {
+			<-done
+			l.root.removeBuffer(pending)
+			_ = handleWriteError(l.root.options(), pending.close(l.root))
+		}()
+	}
+	return newLogger.inject(ctx)
+}
+
+// Flush writes the entries held by the logger carried by ctx, if it was created
+// by BufferedContext.
+func (l Logger) Flush(ctx context.Context) error {
+	l = l.extractLogger(ctx)
+	c, ok := l.s.Desugar().Core().(*core)
+	if !ok || c.pending == nil {
+		return nil
+	}
+	return c.pending.flush(l.root)
+}
+
+func (r *root) addBuffer(p *pendingEntries) {
+	r.buffersMu.Lock()
+	defer r.buffersMu.Unlock()
+	if r.buffers == nil {
+		r.buffers = map[*pendingEntries]struct{}{}
+	}
+	r.buffers[p] = struct{}{}
+}
+
+func (r *root) removeBuffer(p *pendingEntries) {
+	r.buffersMu.Lock()
+	defer r.buffersMu.Unlock()
+	delete(r.buffers, p)
+}
+
+// flushBuffers writes the entries held by BufferedContext for the contexts that
+// are not done yet. The lock of r must be held.
+func (r *root) flushBuffers() error {
+	r.buffersMu.Lock()
+	buffers := make([]*pendingEntries, 0, len(r.buffers))
+	for p := range r.buffers {
+		buffers = append(buffers, p)
+	}
+	r.buffersMu.Unlock()
+
+	var err error
+	for _, p := range buffers {
+		err = multierr.Append(err, p.writeLocked(false))
+	}
+	return err
+}
@@ -0,0 +1,161 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) Sync() error {
+	return nil
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestLogger_BufferedContext(t *testing.T) {
+	buf := &lockedBuffer{}
+	l := New(newZapTestLogger(t, buf).Sugar())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx, _ = l.With(ctx, "request_id", "<request-id-value>")
+	ctx = l.BufferedContext(ctx)
+
+	l.Info(ctx, "first")
+	l.Info(ctx, "second")
+	l.Info(ctx, "third")
+	require.Empty(t, buf.String())
+
+	cancel()
+
+	expected := `{"level":"info","msg":"first","request_id":"<request-id-value>"}` + "\n" +
+		`{"level":"info","msg":"second","request_id":"<request-id-value>"}` + "\n" +
+		`{"level":"info","msg":"third","request_id":"<request-id-value>"}` + "\n"
+	require.Eventually(t, func() bool { return buf.String() == expected }, time.Second, time.Millisecond)
+
+	// Entries logged once the context is done are no longer held.
+	l.Info(ctx, "fourth")
+	require.Equal(t, expected+`{"level":"info","msg":"fourth","request_id":"<request-id-value>"}`+"\n", buf.String())
+}
+
+func TestLogger_Flush(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	ctx := l.BufferedContext(context.Background())
+	l.Info(ctx, "first")
+	l.Info(ctx, "second")
+	require.Empty(t, buf.String())
+
+	require.NoError(t, l.Flush(ctx))
+	require.Equal(t,
+		`{"level":"info","msg":"first"}`+"\n"+
+			`{"level":"info","msg":"second"}`+"\n",
+		buf.String(),
+	)
+
+	require.NoError(t, l.Flush(context.Background()))
+}
+
+func TestLogger_BufferedContext_PanicLevels(t *testing.T) {
+	tests := map[string]struct {
+		log func(t *testing.T, l Logger, ctx context.Context)
+	}{
+		"Should write a DPanic entry straight away": {
+			log: func(t *testing.T, l Logger, ctx context.Context) { l.DPanic(ctx, "boom") },
+		},
+		"Should write a Panic entry before panicking": {
+			log: func(t *testing.T, l Logger, ctx context.Context) {
+				require.Panics(t, func() { l.Panic(ctx, "boom") })
+			},
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+			ctx := l.BufferedContext(context.Background())
+			l.Info(ctx, "held")
+			require.Empty(t, buf.String())
+
+			tc.log(t, l, ctx)
+
+			lines := decodeLines(t, buf)
+			require.Len(t, lines, 2)
+			require.Equal(t, "held", lines[0]["msg"])
+			require.Equal(t, "boom", lines[1]["msg"])
+		})
+	}
+}
+
+func TestLogger_BufferedContext_Reconfigure(t *testing.T) {
+	buf := &lockedBuffer{}
+	l := New(newZapTestLogger(t, buf).Sugar())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ctx = l.BufferedContext(ctx)
+	l.Infow(ctx, "before", "password", "<password-value>")
+	require.Empty(t, buf.String())
+
+	// The entry held before the reload is drained with the options it was logged with.
+	require.NoError(t, l.Reconfigure(WithRedactedKeys("password")))
+	require.Equal(t, `{"level":"info","msg":"before","password":"<password-value>"}`+"\n", buf.String())
+
+	l.Infow(ctx, "after", "password", "<password-value>")
+	require.NoError(t, l.Flush(ctx))
+	require.Equal(t,
+		`{"level":"info","msg":"before","password":"<password-value>"}`+"\n"+
+			`{"level":"info","msg":"after","password":"[REDACTED]"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestLogger_BufferedContext_CapturesLazyValues(t *testing.T) {
+	buf := &lockedBuffer{}
+	l := New(newZapTestLogger(t, buf).Sugar())
+
+	ctx := l.BufferedContext(context.Background())
+	state := "during the request"
+	l.Infow(ctx, "something goes here", "state", func() interface{} { return state })
+	state = "after the request"
+	require.NoError(t, l.Flush(ctx))
+
+	require.Equal(t, `{"level":"info","msg":"something goes here","state":"during the request"}`+"\n", buf.String())
+}
+
+func TestLogger_BufferedContext_WriteErrors(t *testing.T) {
+	errs := make(chan error, 1)
+	l := New(newZapTestLogger(t, failingWriteSyncer{}).Sugar(), WithWriteErrorHandler(func(err error) {
+		errs <- err
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = l.BufferedContext(ctx)
+	l.Info(ctx, "something goes here")
+	cancel()
+
+	require.Error(t, <-errs)
+}
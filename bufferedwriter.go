@@ -0,0 +1,76 @@
+package loggy
+
+import (
+	"bufio"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewBuffered creates a Logger like NewProduction that buffers up to size bytes
+// of entries before writing them to ws, saving a write per entry for sinks such
+// as files. Buffered entries are written once the buffer is full, flushInterval
+// after the first of them was buffered, or when Sync or Close is called, which
+// should be done before the program exits. No goroutine runs while nothing is
+// buffered. Errors writing the buffer after flushInterval go to the write error
+// handler, if there is one, and are otherwise returned by the next write or Sync.
+func NewBuffered(ws zapcore.WriteSyncer, size int, flushInterval time.Duration, level zapcore.Level, opts ...Option) Logger {
+	bws := &bufferedWriteSyncer{ws: ws, buf: bufio.NewWriterSize(ws, size), interval: flushInterval}
+	l := build(zapcore.NewJSONEncoder, zap.NewProductionEncoderConfig(), bws, level, newOptions(opts...))
+	bws.handleError = func(err error) {
+		_ = handleWriteError(l.root.options(), err)
+	}
+	return l
+}
+
+// bufferedWriteSyncer buffers the writes to ws.
+type bufferedWriteSyncer struct {
+	mu       sync.Mutex
+	ws       zapcore.WriteSyncer
+	buf      *bufio.Writer
+	interval time.Duration
+	// timer flushes the buffer after interval when set.
+	timer *time.Timer
+	// handleError handles the errors of the flushes of timer. bufio.Writer keeps
+	// returning them, so later writes and syncs fail too.
+	handleError func(error)
+}
+
+func (b *bufferedWriteSyncer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// Flush what is buffered first rather than splitting p across writes.
+	if len(p) > b.buf.Available() && b.buf.Buffered() > 0 {
+		if err := b.buf.Flush(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := b.buf.Write(p)
+	if b.buf.Buffered() > 0 && b.timer == nil && b.interval > 0 {
+		b.timer = time.AfterFunc(b.interval, func() {
+			if err := b.flush(); err != nil {
+				b.handleError(err)
+			}
+		})
+	}
+	return n, err
+}
+
+func (b *bufferedWriteSyncer) Sync() error {
+	if err := b.flush(); err != nil {
+		return err
+	}
+	return b.ws.Sync()
+}
+
+func (b *bufferedWriteSyncer) flush() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	return b.buf.Flush()
+}
@@ -0,0 +1,79 @@
+package loggy
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func bufferedTestConfig() Option {
+	return WithEncoderConfig(zapcore.EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: zapcore.LowercaseLevelEncoder})
+}
+
+func TestNewBuffered_FlushInterval(t *testing.T) {
+	buf := &lockedBuffer{}
+	l := NewBuffered(buf, 4096, 10*time.Millisecond, zapcore.DebugLevel, bufferedTestConfig())
+
+	l.Info(context.Background(), "something goes here")
+	require.Empty(t, buf.String())
+
+	require.Eventually(t, func() bool {
+		return buf.String() == `{"level":"info","msg":"something goes here"}`+"\n"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestNewBuffered_Sync(t *testing.T) {
+	buf := &lockedBuffer{}
+	l := NewBuffered(buf, 4096, time.Hour, zapcore.DebugLevel, bufferedTestConfig())
+
+	l.Info(context.Background(), "first")
+	l.Info(context.Background(), "second")
+	require.Empty(t, buf.String())
+
+	require.NoError(t, l.Sync())
+	require.Equal(t,
+		`{"level":"info","msg":"first"}`+"\n"+
+			`{"level":"info","msg":"second"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestNewBuffered_Size(t *testing.T) {
+	buf := &lockedBuffer{}
+	l := NewBuffered(buf, 64, time.Hour, zapcore.DebugLevel, bufferedTestConfig())
+
+	for i := 0; i < 4; i++ {
+		l.Info(context.Background(), "something goes here")
+	}
+
+	// 64 bytes fit one entry, so all but the last have been written.
+	require.Equal(t, 3, strings.Count(buf.String(), "\n"))
+	require.NoError(t, l.Sync())
+	require.Equal(t, 4, strings.Count(buf.String(), "\n"))
+}
+
+func TestNewBuffered_Level(t *testing.T) {
+	buf := &lockedBuffer{}
+	l := NewBuffered(buf, 4096, time.Hour, zapcore.InfoLevel, bufferedTestConfig())
+
+	l.Debug(context.Background(), "dropped")
+	l.Info(context.Background(), "something goes here")
+
+	require.NoError(t, l.Sync())
+	require.Equal(t, `{"level":"info","msg":"something goes here"}`+"\n", buf.String())
+}
+
+func TestNewBuffered_FlushIntervalErrors(t *testing.T) {
+	errs := make(chan error, 1)
+	l := NewBuffered(failingWriteSyncer{}, 4096, 10*time.Millisecond, zapcore.DebugLevel, bufferedTestConfig(), WithWriteErrorHandler(func(err error) {
+		errs <- err
+	}))
+
+	l.Info(context.Background(), "something goes here")
+
+	require.Error(t, <-errs)
+}
@@ -0,0 +1,62 @@
+package loggy
+
+import (
+	"encoding/base64"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// defaultMaxBytes is the number of bytes a Bytes field encodes unless WithMaxBytes is given.
+const defaultMaxBytes = 1024
+
+// WithMaxBytes sets the number of bytes encoded by fields constructed with Bytes.
+func WithMaxBytes(n int) Option {
+	return func(o *options) {
+		o.maxBytes = n
+	}
+}
+
+// Bytes constructs a field that encodes b in base64 under key, truncated to the
+// limit set with WithMaxBytes, or 1024 bytes by default. The length of b is
+// added under key+"_len", so a truncated value can be recognized. The value is
+// only encoded if the entry is written. A nil slice is encoded as null.
+func Bytes(key string, b []byte) zapcore.Field {
+	return zapcore.Field{Key: key, Type: zapcore.InlineMarshalerType, Interface: bytesValue{key: key, b: b, max: defaultMaxBytes}}
+}
+
+type bytesValue struct {
+	key string
+	b   []byte
+	max int
+}
+
+func (v bytesValue) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if v.b == nil {
+		return enc.AddReflected(v.key, nil)
+	}
+	b := v.b
+	if len(b) > v.max {
+		b = b[:v.max]
+	}
+	enc.AddString(v.key, base64.StdEncoding.EncodeToString(b))
+	enc.AddInt(v.key+"_len", len(v.b))
+	return nil
+}
+
+// limitBytes applies the limit of the options to the fields constructed with Bytes.
+func (r *root) limitBytes(o *options, fields []zapcore.Field) []zapcore.Field {
+	for i, f := range fields {
+		v, ok := f.Interface.(bytesValue)
+		if !ok {
+			continue
+		}
+		if o.maxBytes > 0 {
+			v.max = o.maxBytes
+			fields[i].Interface = v
+		}
+		if len(v.b) > v.max {
+			r.stats.addTruncated(1)
+		}
+	}
+	return fields
+}
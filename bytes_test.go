@@ -0,0 +1,62 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestBytes(t *testing.T) {
+	tests := map[string]struct {
+		data          []byte
+		opts          []Option
+		expected      string
+		wantTruncated uint64
+	}{
+		"Should encode the bytes in base64": {
+			data:     []byte("gopher"),
+			expected: `"data":"Z29waGVy","data_len":6`,
+		},
+		"Should truncate the bytes at the configured cap": {
+			data:          []byte("gopher"),
+			opts:          []Option{WithMaxBytes(3)},
+			expected:      `"data":"Z29w","data_len":6`,
+			wantTruncated: 1,
+		},
+		"Should truncate the bytes at the default cap": {
+			data:          make([]byte, 1<<20),
+			expected:      `"data_len":1048576`,
+			wantTruncated: 1,
+		},
+		"Should encode a nil slice as null": {
+			expected: `"data":null`,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), tc.opts...)
+
+			ctx, _ := l.With(context.Background())
+			l.Infow(ctx, "something goes here", Bytes("data", tc.data))
+
+			require.Contains(t, buf.String(), tc.expected)
+			require.Equal(t, tc.wantTruncated, l.Stats().Truncated)
+		})
+	}
+}
+
+func TestBytes_DefaultCap(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	ctx, _ := l.With(context.Background())
+	l.Infow(ctx, "something goes here", Bytes("data", make([]byte, 1<<20)))
+
+	// 1024 bytes encode to 1368 base64 characters.
+	require.Len(t, decodeLines(t, buf)[0]["data"], 1368)
+}
@@ -0,0 +1,48 @@
+package loggy
+
+import (
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// byteUnits are the binary units ByteSize formats sizes in.
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// ByteSize constructs a field that encodes the size n, in bytes, under key, and
+// its human readable form, such as "1.5 MiB", under key+"_human". Sizes are
+// formatted in binary units with at most one decimal.
+func ByteSize(key string, n int64) zapcore.Field {
+	return zapcore.Field{Key: key, Type: zapcore.InlineMarshalerType, Interface: byteSizeValue{key: key, n: n}}
+}
+
+type byteSizeValue struct {
+	key string
+	n   int64
+}
+
+func (v byteSizeValue) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt64(v.key, v.n)
+	enc.AddString(v.key+"_human", humanBytes(v.n))
+	return nil
+}
+
+func humanBytes(n int64) string {
+	sign := ""
+	size := float64(n)
+	if n < 0 {
+		sign = "-"
+		size = -size
+	}
+	unit := 0
+	for size >= 1024 && unit < len(byteUnits)-1 {
+		size /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return sign + strconv.FormatFloat(size, 'f', 0, 64) + " B"
+	}
+	num := strings.TrimSuffix(strconv.FormatFloat(size, 'f', 1, 64), ".0")
+	return sign + num + " " + byteUnits[unit]
+}
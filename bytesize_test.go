@@ -0,0 +1,37 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestByteSize(t *testing.T) {
+	tests := map[string]struct {
+		n        int64
+		expected string
+	}{
+		"Should format zero":              {n: 0, expected: `"size":0,"size_human":"0 B"`},
+		"Should format bytes":             {n: 512, expected: `"size":512,"size_human":"512 B"`},
+		"Should format kibibytes":         {n: 1024, expected: `"size":1024,"size_human":"1 KiB"`},
+		"Should format mebibytes":         {n: 1572864, expected: `"size":1572864,"size_human":"1.5 MiB"`},
+		"Should format gibibytes":         {n: 5 << 30, expected: `"size":5368709120,"size_human":"5 GiB"`},
+		"Should format a negative size":   {n: -1536, expected: `"size":-1536,"size_human":"-1.5 KiB"`},
+		"Should format the smallest size": {n: math.MinInt64, expected: `"size":-9223372036854775808,"size_human":"-8 EiB"`},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+			l.Infow(context.Background(), "uploaded", ByteSize("size", tc.n))
+
+			require.Equal(t, `{"level":"info","msg":"uploaded",`+tc.expected+"}\n", buf.String())
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package loggy
+
+import (
+	"runtime"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithCallerFunction adds the func field to every entry, naming the function
+// that logged it as package.Function. The function is resolved from the caller
+// zap reports, already adjusted for loggy's own frames, so the zap logger must
+// be built with zap.AddCaller, as the constructors of this package are.
+// Resolving the name is relatively expensive, so it is only done for the
+// entries that are written.
+func WithCallerFunction() Option {
+	return func(o *options) {
+		o.callerFunction = true
+	}
+}
+
+// callerFunction returns the func field of ent, or false if its caller is unknown.
+func callerFunction(o *options, ent zapcore.Entry) (zapcore.Field, bool) {
+	if !o.callerFunction || !ent.Caller.Defined {
+		return zapcore.Field{}, false
+	}
+	fn := runtime.FuncForPC(ent.Caller.PC)
+	if fn == nil {
+		return zapcore.Field{}, false
+	}
+	name := fn.Name()
+	// Keep the last element of the import path: github.com/org/pkg.Func is pkg.Func.
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	return zap.String("func", name), true
+}
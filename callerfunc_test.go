@@ -0,0 +1,33 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithCallerFunction(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf), zap.AddCaller(), zap.AddCallerSkip(1)).Sugar(), WithCallerFunction())
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	l.Infow(ctx, "something goes here", "key", "value")
+	l.Printf(ctx, zapcore.WarnLevel)("something %s here", "goes")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 2)
+	require.Equal(t, "loggy.TestWithCallerFunction", lines[0]["func"])
+	require.Equal(t, "loggy.TestWithCallerFunction", lines[1]["func"])
+}
+
+func TestWithCallerFunction_NoCaller(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithCallerFunction())
+
+	l.Info(context.Background(), "something goes here")
+	require.Equal(t, `{"level":"info","msg":"something goes here"}`+"\n", buf.String())
+}
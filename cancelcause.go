@@ -0,0 +1,28 @@
+//go:build go1.20
+// +build go1.20
+
+package loggy
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// CancelCause constructs a field that encodes the cause ctx was cancelled with,
+// as set with context.WithCancelCause, under cancel_cause. It is skipped when ctx
+// is not done, or when it was cancelled without a cause, so that the cause is
+// only logged when it tells more than context.Canceled. It is only available
+// from Go 1.20.
+func CancelCause(ctx context.Context) zapcore.Field {
+	err := ctx.Err()
+	if err == nil {
+		return zap.Skip()
+	}
+	cause := context.Cause(ctx)
+	if cause == nil || cause == err {
+		return zap.Skip()
+	}
+	return zap.NamedError("cancel_cause", cause)
+}
@@ -0,0 +1,64 @@
+//go:build go1.20
+// +build go1.20
+
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestCancelCause(t *testing.T) {
+	errShutdown := errors.New("server shutting down")
+	tests := map[string]struct {
+		ctx      func(t *testing.T) context.Context
+		expected string
+	}{
+		"Should skip a context that is not done": {
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithCancelCause(context.Background())
+				t.Cleanup(func() { cancel(nil) })
+				return ctx
+			},
+		},
+		"Should skip a context cancelled without a cause": {
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx
+			},
+		},
+		"Should skip a context cancelled with a nil cause": {
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithCancelCause(context.Background())
+				cancel(nil)
+				return ctx
+			},
+		},
+		"Should encode the cause of a cancelled context": {
+			ctx: func(t *testing.T) context.Context {
+				ctx, cancel := context.WithCancelCause(context.Background())
+				cancel(errShutdown)
+				return ctx
+			},
+			expected: `,"cancel_cause":"server shutting down"`,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+			ctx := tc.ctx(t)
+			l.Infow(ctx, "something goes here", CancelCause(ctx))
+
+			require.Equal(t, `{"level":"info","msg":"something goes here"`+tc.expected+"}\n", buf.String())
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Package chimiddleware adapts the HTTP middleware of loggy to the chi router.
+// It lives in its own package so that only the programs using it depend on chi.
+package chimiddleware
+
+import (
+	"net/http"
+
+	"github.com/ahmedalhulaibi/loggy"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// requestIDKey is the field key of the request ID set by chi's RequestID middleware.
+const requestIDKey = "request_id"
+
+// RequestLogger returns chi middleware that injects a child of l into the context
+// of every request, like Logger.Middleware with opts. When chi's RequestID
+// middleware runs before it, the request ID is attached as the request_id field.
+func RequestLogger(l loggy.Logger, opts ...loggy.MiddlewareOption) func(http.Handler) http.Handler {
+	mw := l.Middleware(opts...)
+	return func(next http.Handler) http.Handler {
+		return mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if id := middleware.GetReqID(r.Context()); id != "" {
+				ctx, _ := l.With(r.Context(), requestIDKey, id)
+				r = r.WithContext(ctx)
+			}
+			next.ServeHTTP(w, r)
+		}))
+	}
+}
@@ -0,0 +1,59 @@
+package chimiddleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahmedalhulaibi/loggy"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func newLogger(buf *bytes.Buffer) loggy.Logger {
+	encoderCfg := zapcore.EncoderConfig{
+		MessageKey:  "msg",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	}
+	return loggy.New(zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderCfg), zapcore.AddSync(buf), zap.DebugLevel)).Sugar())
+}
+
+func TestRequestLogger(t *testing.T) {
+	tests := map[string]struct {
+		middlewares []func(http.Handler) http.Handler
+		expected    string
+	}{
+		"Should attach the chi request ID": {
+			middlewares: []func(http.Handler) http.Handler{middleware.RequestID},
+			expected:    `{"level":"info","msg":"handled","request_id":"<request-id-value>"}` + "\n",
+		},
+		"Should log without the chi request ID middleware": {
+			expected: `{"level":"info","msg":"handled"}` + "\n",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := newLogger(buf)
+
+			r := chi.NewRouter()
+			r.Use(tc.middlewares...)
+			r.Use(RequestLogger(l))
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+				l.Info(r.Context(), "handled")
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(middleware.RequestIDHeader, "<request-id-value>")
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
@@ -0,0 +1,23 @@
+module github.com/ahmedalhulaibi/loggy/chimiddleware
+
+go 1.21
+
+require (
+	github.com/ahmedalhulaibi/loggy v0.0.0
+	github.com/go-chi/chi/v5 v5.0.12
+	github.com/stretchr/testify v1.7.1
+	go.uber.org/zap v1.18.1
+)
+
+require (
+	github.com/coreos/go-systemd/v22 v22.5.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel v1.7.0 // indirect
+	go.opentelemetry.io/otel/trace v1.7.0 // indirect
+	go.uber.org/atomic v1.7.0 // indirect
+	go.uber.org/multierr v1.6.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+)
+
+replace github.com/ahmedalhulaibi/loggy => ../
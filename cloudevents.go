@@ -0,0 +1,76 @@
+package loggy
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// cloudEventsSpecVersion is the version of the CloudEvents specification the
+// entries of NewCloudEvents follow.
+const cloudEventsSpecVersion = "1.0"
+
+// newEventID is swapped in tests to make the IDs of CloudEvents predictable.
+var newEventID = UUIDv4
+
+// NewCloudEvents creates a Logger that writes entries at level and above to ws as
+// CloudEvents in their structured JSON format, so that event-driven platforms can
+// consume them directly. Every entry is an event from source, of the type
+// loggy.log.<level>, such as loggy.log.info, with a random ID and the time of the
+// entry. Its data is the entry encoded as JSON, using zap's production encoder
+// config without the time unless WithEncoderConfig is given.
+func NewCloudEvents(ws zapcore.WriteSyncer, source string, level zapcore.Level, opts ...Option) Logger {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.TimeKey = ""
+	quotedSource, _ := json.Marshal(source)
+	newEncoder := func(cfg zapcore.EncoderConfig) zapcore.Encoder {
+		lineEnding := cfg.LineEnding
+		if lineEnding == "" {
+			lineEnding = zapcore.DefaultLineEnding
+		}
+		return cloudEventsEncoder{Encoder: zapcore.NewJSONEncoder(cfg), source: string(quotedSource), lineEnding: lineEnding}
+	}
+	return build(newEncoder, cfg, ws, level, newOptions(opts...))
+}
+
+var cloudEventsPool = buffer.NewPool()
+
+// cloudEventsEncoder wraps the entries encoded by a JSON encoder in a CloudEvents envelope.
+type cloudEventsEncoder struct {
+	zapcore.Encoder
+	// source is the source of the events, encoded as a JSON string.
+	source     string
+	lineEnding string
+}
+
+func (enc cloudEventsEncoder) Clone() zapcore.Encoder {
+	return cloudEventsEncoder{Encoder: enc.Encoder.Clone(), source: enc.source, lineEnding: enc.lineEnding}
+}
+
+func (enc cloudEventsEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	data, err := enc.Encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Free()
+
+	// The ID, type and time are made of characters JSON strings need not escape.
+	buf := cloudEventsPool.Get()
+	buf.AppendString(`{"specversion":"` + cloudEventsSpecVersion + `","id":"`)
+	buf.AppendString(newEventID())
+	buf.AppendString(`","source":`)
+	buf.AppendString(enc.source)
+	buf.AppendString(`,"type":"loggy.log.`)
+	buf.AppendString(ent.Level.String())
+	buf.AppendString(`","time":"`)
+	buf.AppendTime(ent.Time.UTC(), time.RFC3339Nano)
+	buf.AppendString(`","datacontenttype":"application/json","data":`)
+	buf.Write(bytes.TrimSuffix(data.Bytes(), []byte(enc.lineEnding)))
+	buf.AppendByte('}')
+	buf.AppendString(enc.lineEnding)
+	return buf, nil
+}
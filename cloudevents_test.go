@@ -0,0 +1,54 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewCloudEvents(t *testing.T) {
+	var ids int
+	newEventID = func() string {
+		ids++
+		return fmt.Sprintf("<event-id-%d>", ids)
+	}
+	defer func() { newEventID = UUIDv4 }()
+
+	buf := bytes.NewBuffer([]byte{})
+	l := NewCloudEvents(zapcore.AddSync(buf), "/orders/api", zapcore.InfoLevel, WithEncoderConfig(zapcore.EncoderConfig{
+		MessageKey:  "msg",
+		LevelKey:    "level",
+		NameKey:     "logger",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	}))
+
+	ctx, _ := l.Named(context.Background(), "app")
+	ctx, _ = l.With(ctx, "request_id", "<request-id-value>")
+	at := time.Date(2019, 3, 14, 15, 9, 26, 535897000, time.UTC)
+	l.LogAt(ctx, at, zapcore.InfoLevel, "order placed", "order_id", 7)
+	l.LogAt(ctx, at.Add(time.Second), zapcore.ErrorLevel, "payment failed", "reason", `card "declined"`)
+
+	if *updateGolden {
+		t.Log("Updating golden file:", goldenFilename(t))
+		require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+		require.NoError(t, os.WriteFile(goldenFilename(t), buf.Bytes(), 0644))
+	}
+
+	golden, err := os.ReadFile(goldenFilename(t))
+	require.NoError(t, err)
+	require.Equal(t, buf.Bytes(), golden)
+
+	for _, event := range decodeLines(t, buf) {
+		for _, attr := range []string{"specversion", "id", "source", "type", "time", "data"} {
+			require.Contains(t, event, attr)
+		}
+		require.Equal(t, "1.0", event["specversion"])
+	}
+}
@@ -0,0 +1,83 @@
+package loggy
+
+import (
+	"bytes"
+	"strconv"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// Color is an ANSI terminal foreground color.
+type Color uint8
+
+// Colors for WithFieldColor.
+const (
+	Red Color = iota + 31
+	Green
+	Yellow
+	Blue
+	Magenta
+	Cyan
+)
+
+// WithFieldColor tints every line written by the constructors of this package,
+// such as NewConsole, with the color colors maps the value of its key field to,
+// such as a color per component. Lines whose key field is missing or has a value
+// without a color are left as they are. It is meant for local development, on a
+// terminal understanding ANSI escape codes.
+func WithFieldColor(key string, colors map[string]Color) Option {
+	return func(o *options) {
+		o.colorKey = key
+		o.colors = colors
+	}
+}
+
+var colorPool = buffer.NewPool()
+
+// colorEncoder tints the entries encoded by an encoder after the value of a field.
+type colorEncoder struct {
+	zapcore.Encoder
+	key        string
+	colors     map[string]Color
+	lineEnding string
+}
+
+func (enc colorEncoder) Clone() zapcore.Encoder {
+	return colorEncoder{Encoder: enc.Encoder.Clone(), key: enc.key, colors: enc.colors, lineEnding: enc.lineEnding}
+}
+
+func (enc colorEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line, err := enc.Encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return nil, err
+	}
+	color, ok := enc.color(fields)
+	if !ok {
+		return line, nil
+	}
+	defer line.Free()
+
+	buf := colorPool.Get()
+	buf.AppendString("\x1b[")
+	buf.AppendString(strconv.Itoa(int(color)))
+	buf.AppendByte('m')
+	buf.Write(bytes.TrimSuffix(line.Bytes(), []byte(enc.lineEnding)))
+	buf.AppendString("\x1b[0m")
+	buf.AppendString(enc.lineEnding)
+	return buf, nil
+}
+
+// color returns the color of the last key field of fields, if it has one.
+func (enc colorEncoder) color(fields []zapcore.Field) (Color, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		if f := fields[i]; f.Key == enc.key {
+			if f.Type != zapcore.StringType {
+				return 0, false
+			}
+			color, ok := enc.colors[f.String]
+			return color, ok
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,41 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithFieldColor(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := NewConsole(zapcore.AddSync(buf), zapcore.DebugLevel,
+		WithEncoderConfig(zapcore.EncoderConfig{
+			MessageKey:  "msg",
+			LevelKey:    "level",
+			EncodeLevel: zapcore.LowercaseLevelEncoder,
+		}),
+		WithFieldColor("component", map[string]Color{"cache": Cyan, "db": Magenta}),
+	)
+
+	cache, _ := l.WithComponent(context.Background(), "cache")
+	db, _ := l.WithComponent(context.Background(), "db")
+	queue, _ := l.WithComponent(context.Background(), "queue")
+	l.Infow(cache, "something goes here", "key", "value")
+	l.Warn(db, "slow query")
+	l.Info(queue, "no color")
+
+	if *updateGolden {
+		t.Log("Updating golden file:", goldenFilename(t))
+		require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+		require.NoError(t, os.WriteFile(goldenFilename(t), buf.Bytes(), 0644))
+	}
+
+	golden, err := os.ReadFile(goldenFilename(t))
+	require.NoError(t, err)
+	require.Equal(t, buf.Bytes(), golden)
+}
@@ -0,0 +1,107 @@
+package loggy
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewProduction creates a Logger that writes JSON entries at level and above to ws,
+// using zap's production encoder config unless WithEncoderConfig is given.
+func NewProduction(ws zapcore.WriteSyncer, level zapcore.Level, opts ...Option) Logger {
+	return build(zapcore.NewJSONEncoder, zap.NewProductionEncoderConfig(), ws, level, newOptions(opts...))
+}
+
+// NewConsole creates a Logger that writes human readable entries at level and above
+// to ws, using zap's development encoder config unless WithEncoderConfig is given.
+func NewConsole(ws zapcore.WriteSyncer, level zapcore.Level, opts ...Option) Logger {
+	return build(zapcore.NewConsoleEncoder, zap.NewDevelopmentEncoderConfig(), ws, level, newOptions(opts...))
+}
+
+// Nop creates a Logger that never writes anything. It reports true from IsNop.
+func Nop(opts ...Option) Logger {
+	l := New(zap.NewNop().Sugar(), opts...)
+	l.root.nop = true
+	return l
+}
+
+// IsNop reports whether l, and every logger derived from it, was created by Nop,
+// so expensive work done only to log can be skipped entirely.
+func (l Logger) IsNop() bool {
+	return l.root != nil && l.root.nop
+}
+
+// WithEncoderConfig replaces the encoder config used by the constructors of this
+// package, such as NewProduction and NewConsole. The message and level keys are
+// expected to be set; a warning is logged when either is missing.
+func WithEncoderConfig(cfg zapcore.EncoderConfig) Option {
+	return func(o *options) {
+		o.encoderConfig = &cfg
+	}
+}
+
+// WithLevelEncoder sets how levels are encoded by the constructors of this
+// package, overriding the level encoder of the encoder config.
+func WithLevelEncoder(enc zapcore.LevelEncoder) Option {
+	return func(o *options) {
+		o.levelEncoder = enc
+	}
+}
+
+// WithCallerEncoder sets how the caller is encoded by the constructors of this
+// package, overriding the caller encoder of the encoder config. By default the
+// caller is encoded in its short form, package/file.go:line.
+func WithCallerEncoder(enc zapcore.CallerEncoder) Option {
+	return func(o *options) {
+		o.callerEncoder = enc
+	}
+}
+
+// SyslogLevelEncoder encodes levels as numeric syslog severities, from 7 for
+// DebugLevel down to 2, critical, for DPanicLevel and above.
+func SyslogLevelEncoder(lvl zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	enc.AppendInt(int(journalPriority(lvl)))
+}
+
+func build(newEncoder func(zapcore.EncoderConfig) zapcore.Encoder, cfg zapcore.EncoderConfig, ws zapcore.WriteSyncer, level zapcore.Level, o *options) Logger {
+	if o.encoderConfig != nil {
+		cfg = *o.encoderConfig
+	}
+	if o.levelEncoder != nil {
+		cfg.EncodeLevel = o.levelEncoder
+	}
+	if o.callerEncoder != nil {
+		cfg.EncodeCaller = o.callerEncoder
+	}
+	if cfg.EncodeCaller == nil {
+		cfg.EncodeCaller = zapcore.ShortCallerEncoder
+	}
+	lineEnding := cfg.LineEnding
+	if lineEnding == "" {
+		lineEnding = zapcore.DefaultLineEnding
+	}
+	if o.linePrefix != "" || o.lineSuffix != "" {
+		ws = framingWriter{WriteSyncer: ws, prefix: o.linePrefix, suffix: o.lineSuffix, lineEnding: lineEnding}
+	}
+	encoder := newEncoder(cfg)
+	if o.colorKey != "" {
+		encoder = colorEncoder{Encoder: encoder, key: o.colorKey, colors: o.colors, lineEnding: lineEnding}
+	}
+
+	// The zap core enables every level, so that loggy alone decides which are
+	// enabled, and SetLevel and OverrideLevel can enable levels below level.
+	// Skip the frame of the Logger method so the caller is the log site.
+	zapLogger := zap.New(zapcore.NewCore(encoder, ws, zapcore.DebugLevel), zap.AddCaller(), zap.AddCallerSkip(1))
+	l := newLogger(zapLogger.Sugar(), o, level)
+
+	var missing []string
+	if cfg.MessageKey == "" {
+		missing = append(missing, "message")
+	}
+	if cfg.LevelKey == "" {
+		missing = append(missing, "level")
+	}
+	if len(missing) > 0 {
+		l.s.Warnw("loggy: encoder config is missing required keys", "missing_keys", missing)
+	}
+	return l
+}
@@ -0,0 +1,158 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithEncoderConfig(t *testing.T) {
+	tests := map[string]struct {
+		newLogger func(zapcore.WriteSyncer, zapcore.Level, ...Option) Logger
+		want      string
+	}{
+		"Should honor the line ending with NewProduction": {
+			newLogger: NewProduction,
+			want:      `{"level":"info","msg":"something goes here","request_id":"<request-id-value>"}` + "\r\n",
+		},
+		"Should honor the line ending with NewConsole": {
+			newLogger: NewConsole,
+			want:      "info\tsomething goes here\t" + `{"request_id": "<request-id-value>"}` + "\r\n",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := tc.newLogger(zapcore.AddSync(buf), zapcore.InfoLevel, WithEncoderConfig(zapcore.EncoderConfig{
+				MessageKey:  "msg",
+				LevelKey:    "level",
+				EncodeLevel: zapcore.LowercaseLevelEncoder,
+				LineEnding:  "\r\n",
+			}))
+
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+			l.Info(ctx, "something goes here")
+
+			require.Equal(t, tc.want, buf.String())
+		})
+	}
+}
+
+func TestWithEncoderConfig_WarnsOnMissingKeys(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	NewProduction(zapcore.AddSync(buf), zapcore.InfoLevel, WithEncoderConfig(zapcore.EncoderConfig{
+		MessageKey: "msg",
+	}))
+
+	require.Equal(t, `{"msg":"loggy: encoder config is missing required keys","missing_keys":["level"]}`+"\n", buf.String())
+}
+
+func TestSyslogLevelEncoder(t *testing.T) {
+	tests := map[string]struct {
+		level zapcore.Level
+	}{
+		"Should encode debug level":  {level: zapcore.DebugLevel},
+		"Should encode info level":   {level: zapcore.InfoLevel},
+		"Should encode warn level":   {level: zapcore.WarnLevel},
+		"Should encode error level":  {level: zapcore.ErrorLevel},
+		"Should encode dpanic level": {level: zapcore.DPanicLevel},
+		"Should encode panic level":  {level: zapcore.PanicLevel},
+		"Should encode fatal level":  {level: zapcore.FatalLevel},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := NewProduction(zapcore.AddSync(buf), zapcore.DebugLevel,
+				WithEncoderConfig(zapcore.EncoderConfig{MessageKey: "msg", LevelKey: "level"}),
+				WithLevelEncoder(SyslogLevelEncoder),
+			)
+
+			// Write through the core so panic and fatal entries do not panic or exit.
+			ent := zapcore.Entry{Level: tc.level, Message: "something goes here"}
+			require.NoError(t, l.s.Desugar().Core().Write(ent, nil))
+
+			if *updateGolden {
+				t.Log("Updating golden file:", goldenFilename(t))
+				require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+				require.NoError(t, os.WriteFile(goldenFilename(t), buf.Bytes(), 0644))
+			}
+
+			golden, err := os.ReadFile(goldenFilename(t))
+			require.NoError(t, err)
+			require.Equal(t, buf.Bytes(), golden)
+		})
+	}
+}
+
+func TestWithCallerEncoder(t *testing.T) {
+	tests := map[string]struct {
+		opts []Option
+	}{
+		"Should encode the short caller by default": {},
+		"Should encode the caller with the given encoder": {
+			opts: []Option{WithCallerEncoder(func(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
+				enc.AppendString("<caller>")
+			})},
+		},
+	}
+	// The short caller starts with the directory of the checkout, which varies.
+	checkoutDir := regexp.MustCompile(`"caller":"[^"/]+/`)
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			opts := append([]Option{WithEncoderConfig(zapcore.EncoderConfig{
+				MessageKey:  "msg",
+				LevelKey:    "level",
+				CallerKey:   "caller",
+				EncodeLevel: zapcore.LowercaseLevelEncoder,
+			})}, tc.opts...)
+			l := NewProduction(zapcore.AddSync(buf), zapcore.DebugLevel, opts...)
+
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+			_, _, line, _ := runtime.Caller(0)
+			l.Info(ctx, "something goes here")
+			l.Printf(ctx, zapcore.InfoLevel)("something goes %s", "here")
+
+			got := checkoutDir.ReplaceAll(buf.Bytes(), []byte(`"caller":"<dir>/`))
+			// Replace the line numbers of the log sites, so the golden file does not
+			// change whenever this file does.
+			got = bytes.Replace(got, []byte(fmt.Sprintf("constructors_test.go:%d", line+1)), []byte("constructors_test.go:<info-line>"), 1)
+			got = bytes.Replace(got, []byte(fmt.Sprintf("constructors_test.go:%d", line+2)), []byte("constructors_test.go:<printf-line>"), 1)
+
+			if *updateGolden {
+				t.Log("Updating golden file:", goldenFilename(t))
+				require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+				require.NoError(t, os.WriteFile(goldenFilename(t), got, 0644))
+			}
+
+			golden, err := os.ReadFile(goldenFilename(t))
+			require.NoError(t, err)
+			require.Equal(t, got, golden)
+		})
+	}
+}
+
+func TestNop(t *testing.T) {
+	l := Nop()
+	require.True(t, l.IsNop())
+
+	ctx, child := l.With(context.Background(), "request_id", "<request-id-value>")
+	require.True(t, child.IsNop())
+	l.Info(ctx, "something goes here")
+
+	require.False(t, NewProduction(zapcore.AddSync(bytes.NewBuffer([]byte{})), zapcore.InfoLevel).IsNop())
+	require.False(t, New(zap.NewNop().Sugar()).IsNop())
+	require.False(t, Logger{}.IsNop())
+}
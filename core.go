@@ -0,0 +1,303 @@
+package loggy
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// root holds the state shared by a Logger and every logger derived from it.
+// Options are read on every entry so that Reconfigure is observed by all of them.
+type root struct {
+	// The times, in Unix nanoseconds, the last throttled warnings were logged.
+	// They come first to be 64-bit aligned for atomic operations on 32-bit platforms.
+	lastMissingLoggerWarning int64
+	lastMessageWarning       int64
+	lastKeyWarning           int64
+
+	opts    atomic.Value // *options
+	optsMu  sync.Mutex   // serializes the updates of opts
+	stats   stats
+	limiter rateLimiter
+	sampler sampler
+
+	// mu is held by the writes of batches, and read-held by the other writes, so
+	// batches are written contiguously while other writes do not wait on each
+	// other. It is shared with the clones of the root.
+	mu *sync.RWMutex
+
+	// buffers are the entries held by BufferedContext for the contexts that are
+	// not done yet, which Reconfigure writes.
+	buffersMu sync.Mutex
+	buffers   map[*pendingEntries]struct{}
+
+	// contextKey is the key the loggers are stored under in a context.
+	contextKey logContextKey
+
+	// nop is set for the loggers created by Nop.
+	nop bool
+
+	// defaultLevel is the level of the options that do not set one, such as the
+	// level given to the constructors of this package. It outlives Reconfigure.
+	defaultLevel zapcore.LevelEnabler
+}
+
+func newRoot(o *options, defaultLevel zapcore.LevelEnabler) *root {
+	r := &root{defaultLevel: defaultLevel, mu: &sync.RWMutex{}}
+	r.setOptions(o)
+	return r
+}
+
+// setOptions makes o the options of r, falling back to its default level.
+func (r *root) setOptions(o *options) {
+	if o.level == nil {
+		o.level = r.defaultLevel
+	}
+	r.optsMu.Lock()
+	defer r.optsMu.Unlock()
+	r.opts.Store(o)
+}
+
+func (r *root) options() *options {
+	return r.opts.Load().(*options)
+}
+
+// core wraps the zapcore.Core of the underlying zap logger and processes fields
+// before they are handed to it for encoding. Fields added through With are kept
+// unencoded so they can be processed together with the fields passed at the log site.
+type core struct {
+	root   *root
+	base   zapcore.Core
+	fields []zapcore.Field
+
+	// name is the name given to the logger with Named, including the name of the
+	// zap logger passed to New, so it matches the logger name of its entries.
+	name string
+	// level overrides the minimum level of the options when set.
+	level zapcore.LevelEnabler
+	// sampler replaces the sampler of the root when set.
+	sampler *sampler
+	// summary counts entries instead of writing them when set.
+	summary *requestSummary
+	// pending collects entries instead of writing them when set.
+	pending *pendingEntries
+	// entries counts the entries checked against the per-request entry cap when set.
+	entries *entryCount
+	// audit writes every entry, bypassing levels, sampling and limits.
+	audit bool
+	// escalate logs Debug and Info entries at WarnLevel, under deadline pressure.
+	escalate bool
+	// ctx is the context passed at the log site, set for the base cores that
+	// implement contextCore.
+	ctx context.Context
+}
+
+// contextCore is implemented by the zap cores that take the context passed at
+// the log site along with an entry, such as the core of NewFromSlog.
+type contextCore interface {
+	zapcore.Core
+	WriteContext(ctx context.Context, ent zapcore.Entry, fields []zapcore.Field) error
+}
+
+// withCore returns a copy of s whose core is modified by fn.
+func withCore(s *zap.SugaredLogger, fn func(c *core)) *zap.SugaredLogger {
+	return s.Desugar().WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		clone := *c.(*core)
+		fn(&clone)
+		return &clone
+	})).Sugar()
+}
+
+// writeBase writes ent to the base core, along with the context passed at the
+// log site when the base core takes one.
+func (c *core) writeBase(ent zapcore.Entry, fields []zapcore.Field) error {
+	if cc, ok := c.base.(contextCore); ok && c.ctx != nil {
+		return cc.WriteContext(c.ctx, ent, fields)
+	}
+	return c.base.Write(ent, fields)
+}
+
+func (c *core) Enabled(lvl zapcore.Level) bool {
+	if c.audit {
+		return true
+	}
+	o := c.root.options()
+	if o.mutedLevels.has(lvl) || !c.base.Enabled(lvl) {
+		return false
+	}
+	if c.level != nil {
+		return c.level.Enabled(lvl)
+	}
+	if len(o.levelsByName) > 0 && lvl >= o.minNamedLevel {
+		// Whether the entry is enabled depends on the logger name, checked in Check.
+		return true
+	}
+	return o.level == nil || o.level.Enabled(lvl)
+}
+
+func (c *core) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	// Force a copy so siblings derived from the same parent do not share a backing array.
+	clone.fields = append(c.fields[:len(c.fields):len(c.fields)], fields...)
+	return &clone
+}
+
+func (c *core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.audit {
+		return ce.AddCore(ent, c)
+	}
+	if !c.enabled(ent.Level, ent.LoggerName) {
+		return ce
+	}
+	// Only entries enabled at their own level are escalated.
+	escalated := c.escalate && ent.Level < zapcore.WarnLevel
+	if escalated {
+		ent.Level = zapcore.WarnLevel
+	}
+	if !c.sample(ent) || !c.rateLimit(ent) || !c.withinCap(ent) {
+		return ce
+	}
+	if escalated {
+		return ce.AddCore(ent, escalatedCore{c})
+	}
+	return ce.AddCore(ent, c)
+}
+
+// enabled reports whether an entry at lvl from the logger named name is written.
+// Unlike Enabled, it resolves the levels set by name.
+func (c *core) enabled(lvl zapcore.Level, name string) bool {
+	if !c.Enabled(lvl) {
+		return false
+	}
+	if c.level != nil {
+		return true
+	}
+	min := c.root.options().levelFor(name)
+	return min == nil || min.Enabled(lvl)
+}
+
+func (c *core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	o := c.root.options()
+	if c.summary != nil && !c.summary.add(o, ent) {
+		return nil
+	}
+
+	lvl := ent.Level
+	ent, all := c.process(o, ent, fields)
+
+	var err error
+	if c.pending != nil {
+		if lvl < zapcore.DPanicLevel && c.pending.add(c, ent, all) {
+			return nil
+		}
+		// zap may panic or exit once an entry at DPanicLevel and above is written,
+		// so it is written straight away, after the entries held before it.
+		err = c.pending.flush(c.root)
+	}
+
+	c.root.mu.RLock()
+	err = multierr.Append(err, c.writeBase(ent, all))
+	c.root.mu.RUnlock()
+	return handleWriteError(o, err)
+}
+
+// process runs ent and its fields, on top of the fields of c, through the
+// processing configured by o, and returns what is to be written.
+func (c *core) process(o *options, ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+	levelFields := o.levelFields[ent.Level]
+	all := make([]zapcore.Field, 0, len(o.fields)+len(levelFields)+len(c.fields)+len(fields))
+	all = append(all, o.fields...)
+	all = append(all, levelFields...)
+	all = append(all, c.fields...)
+	all = append(all, fields...)
+	if f, ok := callerFunction(o, ent); ok {
+		all = append(all, f)
+	}
+	if o.entryUUID {
+		all = append(all, entryID())
+	}
+
+	ent = nameFromField(o, ent, all)
+	if !c.audit {
+		// Audit actions are kept as logged.
+		ent, all = c.validateMessage(o, ent, all)
+	}
+	all = c.validateKeys(o, ent, all)
+	if o.messageCounter {
+		c.root.stats.messages.add(ent.Level, ent.Message)
+	}
+	all = resolveLazy(all)
+	all = serialize(all)
+	all = c.root.limitBytes(o, all)
+	ent, all = c.root.trimStacks(o, ent, all)
+	all = c.root.maskIPs(o, all)
+	all = c.root.limitDepth(o, all)
+	all = formatTimes(o, all)
+	all = c.alias(o, ent, all)
+	all = c.root.dedupe(o, all)
+	all = c.encryptFields(o, ent, all)
+	all = c.root.redact(o, all)
+	return ent, all
+}
+
+// warn writes a warning about ent straight to the base core, bypassing field
+// processing. It is used to report problems found while processing ent's fields.
+// Warnings are only written when WarnLevel is enabled by the options and the base core.
+func (c *core) warn(ent zapcore.Entry, msg string, fields ...zapcore.Field) {
+	if lvl := c.root.options().level; !c.base.Enabled(zapcore.WarnLevel) || (lvl != nil && !lvl.Enabled(zapcore.WarnLevel)) {
+		return
+	}
+	c.root.mu.RLock()
+	defer c.root.mu.RUnlock()
+	_ = c.base.Write(zapcore.Entry{
+		Level:      zapcore.WarnLevel,
+		Time:       ent.Time,
+		LoggerName: ent.LoggerName,
+		Message:    msg,
+	}, fields)
+}
+
+// validationWarningInterval is the minimum interval between two warnings of the
+// same validator, so that a hot log site failing validation does not double
+// the volume of the logs.
+const validationWarningInterval = time.Second
+
+// allowWarning reports whether a warning throttled to one per interval can be
+// logged now, given last, the time the previous one was logged in Unix
+// nanoseconds, and records the time of this one in last if so.
+func allowWarning(last *int64, interval time.Duration) bool {
+	t := now().UnixNano()
+	prev := atomic.LoadInt64(last)
+	if prev != 0 && t-prev < int64(interval) {
+		return false
+	}
+	return atomic.CompareAndSwapInt64(last, prev, t)
+}
+
+func (c *core) Sync() error {
+	return handleWriteError(c.root.options(), c.base.Sync())
+}
+
+// WithWriteErrorHandler calls handle with the errors of writing and syncing
+// entries, instead of reporting them to the error output of the zap logger.
+// Tests can set it to t.Error so that a failing write fails the test.
+func WithWriteErrorHandler(handle func(error)) Option {
+	return func(o *options) {
+		o.writeErrorHandler = handle
+	}
+}
+
+// handleWriteError hands err to the write error handler, if there is one.
+// It returns err when zap is left to report it.
+func handleWriteError(o *options, err error) error {
+	if err == nil || o.writeErrorHandler == nil {
+		return err
+	}
+	o.writeErrorHandler(err)
+	return nil
+}
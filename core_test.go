@@ -0,0 +1,44 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// failingWriteSyncer fails every write and sync.
+type failingWriteSyncer struct{}
+
+func (failingWriteSyncer) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+func (failingWriteSyncer) Sync() error               { return errors.New("sync failed") }
+
+func TestWithWriteErrorHandler(t *testing.T) {
+	errOut := bytes.NewBuffer([]byte{})
+	var handled []error
+	l := New(newZapTestLogger(t, failingWriteSyncer{}, zap.ErrorOutput(zapcore.AddSync(errOut))).Sugar(),
+		WithWriteErrorHandler(func(err error) { handled = append(handled, err) }),
+	)
+
+	ctx, _ := l.With(context.Background())
+	l.Info(ctx, "something goes here")
+	require.NoError(t, l.s.Sync())
+
+	require.Len(t, handled, 2)
+	require.EqualError(t, handled[0], "disk full")
+	require.EqualError(t, handled[1], "sync failed")
+	require.Empty(t, errOut.String())
+}
+
+func TestWithWriteErrorHandler_Default(t *testing.T) {
+	errOut := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, failingWriteSyncer{}, zap.ErrorOutput(zapcore.AddSync(errOut))).Sugar())
+
+	l.Info(context.Background(), "something goes here")
+
+	require.Contains(t, errOut.String(), "disk full")
+}
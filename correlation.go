@@ -0,0 +1,60 @@
+package loggy
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CorrelationSource looks up a correlation ID in ctx. It reports false when ctx
+// does not carry one.
+type CorrelationSource func(ctx context.Context) (string, bool)
+
+// WithCorrelationSources attaches the correlation ID found by the first of sources
+// that has one as the correlation_id field. Sources are tried in order at the log
+// site, so the most authoritative one should come first.
+func WithCorrelationSources(sources ...CorrelationSource) Option {
+	return WithContextExtractor(func(ctx context.Context) []interface{} {
+		for _, source := range sources {
+			if id, ok := source(ctx); ok && id != "" {
+				return []interface{}{correlationIDKey, id}
+			}
+		}
+		return nil
+	})
+}
+
+// CorrelationFromContextKey reads the correlation ID from the string stored in
+// ctx under key.
+func CorrelationFromContextKey(key interface{}) CorrelationSource {
+	return func(ctx context.Context) (string, bool) {
+		id, ok := ctx.Value(key).(string)
+		return id, ok
+	}
+}
+
+// CorrelationFromHeader reads the correlation ID from the header named name of
+// the request served by Logger.Middleware. It finds nothing outside of a request.
+func CorrelationFromHeader(name string) CorrelationSource {
+	return func(ctx context.Context) (string, bool) {
+		r, ok := ctx.Value(requestctxkey).(*http.Request)
+		if !ok {
+			return "", false
+		}
+		id := r.Header.Get(name)
+		return id, id != ""
+	}
+}
+
+// CorrelationFromTraceID reads the correlation ID from the trace ID of the
+// OpenTelemetry span carried by ctx.
+func CorrelationFromTraceID() CorrelationSource {
+	return func(ctx context.Context) (string, bool) {
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.HasTraceID() {
+			return "", false
+		}
+		return sc.TraceID().String(), true
+	}
+}
@@ -0,0 +1,78 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+type correlationKey struct{}
+
+func TestWithCorrelationSources(t *testing.T) {
+	traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	sources := []CorrelationSource{
+		CorrelationFromContextKey(correlationKey{}),
+		CorrelationFromHeader("X-Correlation-ID"),
+		CorrelationFromTraceID(),
+	}
+
+	tests := map[string]struct {
+		contextValue string
+		header       string
+		traced       bool
+		want         string
+	}{
+		"Should prefer the context value over every other source": {
+			contextValue: "<context-value>",
+			header:       "<header-value>",
+			traced:       true,
+			want:         `"correlation_id":"<context-value>"`,
+		},
+		"Should fall back to the header when the context value is absent": {
+			header: "<header-value>",
+			traced: true,
+			want:   `"correlation_id":"<header-value>"`,
+		},
+		"Should fall back to the trace ID when no other source has a value": {
+			traced: true,
+			want:   `"correlation_id":"4bf92f3577b34da6a3ce929d0e0e4736"`,
+		},
+		"Should omit the field when no source has a value": {},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithCorrelationSources(sources...))
+
+			handler := l.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				l.Info(r.Context(), "handled")
+			}))
+
+			ctx := context.Background()
+			if tc.contextValue != "" {
+				ctx = context.WithValue(ctx, correlationKey{}, tc.contextValue)
+			}
+			if tc.traced {
+				ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID}))
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+			if tc.header != "" {
+				req.Header.Set("X-Correlation-ID", tc.header)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if tc.want == "" {
+				require.Equal(t, `{"level":"info","msg":"handled"}`+"\n", buf.String())
+				return
+			}
+			require.Contains(t, buf.String(), tc.want)
+		})
+	}
+}
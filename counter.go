@@ -0,0 +1,67 @@
+package loggy
+
+import (
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// maxCountedMessages caps the distinct messages counted per level by WithMessageCounter.
+const maxCountedMessages = 1000
+
+// otherMessages counts the messages past maxCountedMessages.
+const otherMessages = "__other__"
+
+// MessageKey identifies the entries counted by WithMessageCounter.
+type MessageKey struct {
+	Level   zapcore.Level
+	Message string
+}
+
+// WithMessageCounter counts the entries written at each level with each message,
+// reported in Stats().Messages, so metrics can be derived without parsing the
+// logs. Only the first 1000 distinct messages of a level are counted on their
+// own; the rest are counted together under the "__other__" message.
+func WithMessageCounter() Option {
+	return func(o *options) {
+		o.messageCounter = true
+	}
+}
+
+// messageCounts holds the counters of WithMessageCounter.
+type messageCounts struct {
+	mu       sync.Mutex
+	counts   map[MessageKey]uint64
+	distinct map[zapcore.Level]int
+}
+
+func (m *messageCounts) add(lvl zapcore.Level, msg string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.counts == nil {
+		m.counts = make(map[MessageKey]uint64)
+		m.distinct = make(map[zapcore.Level]int)
+	}
+	key := MessageKey{Level: lvl, Message: msg}
+	if _, ok := m.counts[key]; !ok {
+		if m.distinct[lvl] >= maxCountedMessages {
+			key.Message = otherMessages
+		} else {
+			m.distinct[lvl]++
+		}
+	}
+	m.counts[key]++
+}
+
+func (m *messageCounts) snapshot() map[MessageKey]uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.counts) == 0 {
+		return nil
+	}
+	out := make(map[MessageKey]uint64, len(m.counts))
+	for k, n := range m.counts {
+		out[k] = n
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithMessageCounter(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithMessageCounter())
+
+	ctx, _ := l.With(context.Background())
+	l.Info(ctx, "order placed")
+	l.Infow(ctx, "order placed", "key", "value")
+	l.Error(ctx, "order placed")
+
+	require.Equal(t, map[MessageKey]uint64{
+		{Level: zapcore.InfoLevel, Message: "order placed"}:  2,
+		{Level: zapcore.ErrorLevel, Message: "order placed"}: 1,
+	}, l.Stats().Messages)
+}
+
+func TestWithMessageCounter_Overflow(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithMessageCounter())
+
+	ctx, _ := l.With(context.Background())
+	for i := 0; i < maxCountedMessages+2; i++ {
+		l.Info(ctx, "message "+strconv.Itoa(i))
+	}
+	l.Info(ctx, "message 0")
+
+	messages := l.Stats().Messages
+	require.Len(t, messages, maxCountedMessages+1)
+	require.Equal(t, uint64(2), messages[MessageKey{Level: zapcore.InfoLevel, Message: "message 0"}])
+	require.Equal(t, uint64(2), messages[MessageKey{Level: zapcore.InfoLevel, Message: otherMessages}])
+}
+
+func TestLogger_Stats_NoMessageCounter(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	l.Info(context.Background(), "something goes here")
+	require.Nil(t, l.Stats().Messages)
+}
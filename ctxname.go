@@ -0,0 +1,29 @@
+package loggy
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// WithNameFromContextField names every entry after the value of its key field,
+// such as an operation field added to the context with With or AddField, in
+// place of the name given with Named. Entries without a string key field keep
+// their name.
+func WithNameFromContextField(key string) Option {
+	return func(o *options) {
+		o.nameFromField = key
+	}
+}
+
+// nameFromField sets the name of ent from its fields as WithNameFromContextField configures.
+func nameFromField(o *options, ent zapcore.Entry, fields []zapcore.Field) zapcore.Entry {
+	if o.nameFromField == "" {
+		return ent
+	}
+	for i := len(fields) - 1; i >= 0; i-- {
+		if f := fields[i]; f.Key == o.nameFromField && f.Type == zapcore.StringType {
+			ent.LoggerName = f.String
+			break
+		}
+	}
+	return ent
+}
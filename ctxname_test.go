@@ -0,0 +1,54 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithNameFromContextField(t *testing.T) {
+	tests := map[string]struct {
+		args     []interface{}
+		expected string
+	}{
+		"Should name the logger after the field": {
+			args:     []interface{}{"operation", "checkout"},
+			expected: `{"level":"info","logger":"checkout","msg":"something goes here","operation":"checkout"}` + "\n",
+		},
+		"Should keep the name without the field": {
+			args:     []interface{}{"key", "value"},
+			expected: `{"level":"info","msg":"something goes here","key":"value"}` + "\n",
+		},
+		"Should ignore a field that is not a string": {
+			args:     []interface{}{"operation", 7},
+			expected: `{"level":"info","msg":"something goes here","operation":7}` + "\n",
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithNameFromContextField("operation"))
+
+			ctx, _ := l.With(context.Background(), tc.args...)
+			l.Info(ctx, "something goes here")
+
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestWithNameFromContextField_ReplacesName(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithNameFromContextField("operation"))
+
+	ctx, _ := l.Named(context.Background(), "app")
+	ctx = AddField(ctx, "operation", "checkout")
+	l.Info(ctx, "something goes here")
+
+	require.Equal(t, `{"level":"info","logger":"checkout","msg":"something goes here","operation":"checkout"}`+"\n", buf.String())
+}
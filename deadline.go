@@ -0,0 +1,70 @@
+package loggy
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// now is swapped in tests to control the time budget left to a context and
+// the time elapsed since a request started.
+var now = time.Now
+
+// afterFunc calls f in its own goroutine after d, unless stopped first. It is
+// swapped in tests to fire timers without waiting for them.
+var afterFunc = func(d time.Duration, f func()) (stop func() bool) {
+	return time.AfterFunc(d, f).Stop
+}
+
+// WithDeadlinePressureEscalation logs the Debug and Info entries of a context
+// at WarnLevel, with the deadline_pressure field, once less than fraction of
+// its time budget is left. Only entries enabled at their own level are
+// escalated, so it never enables Debug entries WithLevel disables. The budget
+// runs from the start of the request, as recorded by Middleware with
+// WithRequestStart, or else from the time a logger was first injected into the
+// context, to the deadline of the context. Contexts without a deadline are
+// unaffected.
+func WithDeadlinePressureEscalation(fraction float64) Option {
+	return func(o *options) {
+		o.deadlinePressure = fraction
+	}
+}
+
+// markStart records in ctx the time the logger is first injected into it, as the
+// start of the request, when deadline pressure escalation is enabled and the
+// start is not recorded yet.
+func (r *root) markStart(ctx context.Context) context.Context {
+	if r.options().deadlinePressure <= 0 || ctx.Value(requeststartctxkey) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, requeststartctxkey, requestStart{time: now()})
+}
+
+// underDeadlinePressure reports whether less than the configured fraction of the
+// time budget of ctx is left.
+func underDeadlinePressure(o *options, ctx context.Context) bool {
+	if o.deadlinePressure <= 0 {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return false
+	}
+	start, ok := ctx.Value(requeststartctxkey).(requestStart)
+	if !ok {
+		return false
+	}
+	total := deadline.Sub(start.time)
+	return float64(deadline.Sub(now())) < o.deadlinePressure*float64(total)
+}
+
+// escalatedCore writes the entries escalated by deadline pressure.
+type escalatedCore struct {
+	*core
+}
+
+func (c escalatedCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.core.Write(ent, append(fields, zap.Bool("deadline_pressure", true)))
+}
@@ -0,0 +1,82 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithDeadlinePressureEscalation(t *testing.T) {
+	tests := map[string]struct {
+		elapsed  time.Duration
+		expected string
+	}{
+		"Should keep the level with more than the fraction of the budget left": {
+			elapsed:  8900 * time.Millisecond,
+			expected: `{"level":"info","msg":"something goes here","key":"value"}` + "\n",
+		},
+		"Should escalate with less than the fraction of the budget left": {
+			elapsed:  9100 * time.Millisecond,
+			expected: `{"level":"warn","msg":"something goes here","key":"value","deadline_pressure":true}` + "\n",
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			start := time.Now()
+			t.Cleanup(func() { now = time.Now })
+			now = func() time.Time { return start }
+
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithDeadlinePressureEscalation(0.1))
+
+			ctx, _ := l.With(context.Background())
+			ctx, cancel := context.WithDeadline(ctx, start.Add(10*time.Second))
+			defer cancel()
+
+			now = func() time.Time { return start.Add(tc.elapsed) }
+			l.Infow(ctx, "something goes here", "key", "value")
+
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestWithDeadlinePressureEscalation_KeepsDisabledLevels(t *testing.T) {
+	start := time.Now()
+	t.Cleanup(func() { now = time.Now })
+	now = func() time.Time { return start }
+
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithLevel(zapcore.InfoLevel), WithDeadlinePressureEscalation(0.5))
+
+	ctx, _ := l.With(context.Background())
+	ctx, cancel := context.WithDeadline(ctx, start.Add(10*time.Second))
+	defer cancel()
+
+	now = func() time.Time { return start.Add(6 * time.Second) }
+	l.Debug(ctx, "dropped")
+	l.Info(ctx, "something goes here")
+	l.Error(ctx, "unchanged")
+
+	require.Equal(t,
+		`{"level":"warn","msg":"something goes here","deadline_pressure":true}`+"\n"+
+			`{"level":"error","msg":"unchanged"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestWithDeadlinePressureEscalation_NoDeadline(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithDeadlinePressureEscalation(1))
+
+	ctx, _ := l.With(context.Background())
+	l.Info(ctx, "something goes here")
+
+	require.Equal(t, `{"level":"info","msg":"something goes here"}`+"\n", buf.String())
+}
@@ -0,0 +1,131 @@
+package loggy
+
+import (
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DuplicateKeyPolicy decides what happens when the same key is set more than
+// once on an entry, for example by both With and the log site.
+type DuplicateKeyPolicy int
+
+const (
+	// KeepFirst keeps the value that was set first and drops the others.
+	KeepFirst DuplicateKeyPolicy = iota + 1
+	// KeepLast keeps the value that was set last and drops the others.
+	KeepLast
+	// Array collects every value set for the key into an array.
+	Array
+)
+
+// WithDuplicateKeyPolicy resolves duplicate keys according to policy before
+// the entry is encoded. Without it, duplicate keys are passed to the encoder as is.
+func WithDuplicateKeyPolicy(policy DuplicateKeyPolicy) Option {
+	return func(o *options) {
+		o.duplicateKeyPolicy = policy
+	}
+}
+
+// WithMaxArrayLen caps the number of values the Array policy collects for a key
+// at n, so that a loop setting the same key over and over cannot grow an entry
+// without bound. The values past the first n are dropped and replaced by a
+// "...+N more" marker at the end of the array.
+func WithMaxArrayLen(n int) Option {
+	return func(o *options) {
+		o.maxArrayLen = n
+	}
+}
+
+// fieldKey identifies a field within the namespace it was added to.
+type fieldKey struct {
+	namespace int
+	key       string
+}
+
+func (r *root) dedupe(o *options, fields []zapcore.Field) []zapcore.Field {
+	policy := o.duplicateKeyPolicy
+	if policy == 0 || len(fields) < 2 {
+		return fields
+	}
+
+	var (
+		namespace int
+		dropped   uint64
+		seen      = make(map[fieldKey]int, len(fields))
+		groups    map[int]*fieldValues
+		out       = make([]zapcore.Field, 0, len(fields))
+	)
+	for _, f := range fields {
+		if f.Type == zapcore.NamespaceType {
+			namespace++
+			out = append(out, f)
+			continue
+		}
+		if f.Key == "" {
+			out = append(out, f)
+			continue
+		}
+
+		k := fieldKey{namespace: namespace, key: f.Key}
+		i, ok := seen[k]
+		if !ok {
+			seen[k] = len(out)
+			out = append(out, f)
+			continue
+		}
+
+		switch policy {
+		case KeepFirst:
+			dropped++
+		case KeepLast:
+			out[i] = f
+			dropped++
+		case Array:
+			if groups == nil {
+				groups = map[int]*fieldValues{}
+			}
+			values, ok := groups[i]
+			if !ok {
+				values = &fieldValues{fields: []zapcore.Field{out[i]}}
+				groups[i] = values
+			}
+			if o.maxArrayLen > 0 && len(values.fields) >= o.maxArrayLen {
+				values.more++
+				dropped++
+				continue
+			}
+			values.fields = append(values.fields, f)
+		}
+	}
+
+	for i, values := range groups {
+		out[i] = zap.Array(out[i].Key, *values)
+	}
+	if dropped > 0 {
+		r.stats.addDropped(dropped)
+	}
+	return out
+}
+
+// fieldValues encodes the values of fields sharing a key as an array, followed
+// by a marker of the number of values left out, if any.
+type fieldValues struct {
+	fields []zapcore.Field
+	more   int
+}
+
+func (fs fieldValues) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, f := range fs.fields {
+		m := zapcore.NewMapObjectEncoder()
+		f.AddTo(m)
+		if err := enc.AppendReflected(m.Fields[f.Key]); err != nil {
+			return err
+		}
+	}
+	if fs.more > 0 {
+		enc.AppendString("...+" + strconv.Itoa(fs.more) + " more")
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithDuplicateKeyPolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy  DuplicateKeyPolicy
+		dropped uint64
+	}{
+		"Should keep the first value": {
+			policy:  KeepFirst,
+			dropped: 2,
+		},
+		"Should keep the last value": {
+			policy:  KeepLast,
+			dropped: 2,
+		},
+		"Should collect values into an array": {
+			policy:  Array,
+			dropped: 0,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+
+			zapLogger := newZapTestLogger(t, zapcore.AddSync(buf))
+			l := New(zapLogger.Sugar(), WithDuplicateKeyPolicy(tc.policy))
+
+			ctx, _ := l.With(context.Background(), "key", "<context-value>")
+			ctx, _ = l.With(ctx, "key", "<child-value>", zap.Namespace("details"), "key", "<namespaced-value>")
+
+			l.Infow(ctx, "something goes here", "key", "<call-site-value>")
+
+			if *updateGolden {
+				t.Log("Updating golden file:", goldenFilename(t))
+				require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+				require.NoError(t, os.WriteFile(goldenFilename(t), buf.Bytes(), 0644))
+			}
+
+			golden, err := os.ReadFile(goldenFilename(t))
+			require.NoError(t, err)
+			require.Equal(t, buf.Bytes(), golden)
+			require.Equal(t, tc.dropped, l.Stats().Dropped)
+		})
+	}
+}
+
+func TestWithMaxArrayLen(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithDuplicateKeyPolicy(Array), WithMaxArrayLen(5))
+
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		ctx, _ = l.With(ctx, "attempt", i)
+	}
+	l.Info(ctx, "something goes here")
+
+	require.Equal(t,
+		`{"level":"info","msg":"something goes here","attempt":[0,1,2,3,4,"...+95 more"]}`+"\n",
+		buf.String(),
+	)
+	require.Equal(t, uint64(95), l.Stats().Dropped)
+}
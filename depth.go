@@ -0,0 +1,109 @@
+package loggy
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// truncatedMarker replaces the values nested deeper than the limit set with WithMaxFieldDepth.
+const truncatedMarker = "...[truncated]"
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// WithMaxFieldDepth limits how deeply maps, structs, slices and arrays passed as
+// field values are encoded. Values nested more than n levels deep are replaced
+// with "...[truncated]", which keeps entries logging a decoded payload readable.
+// Struct fields are keyed as with WithStructuredSingleArg. Values that marshal
+// themselves, such as time.Time, are encoded as-is.
+func WithMaxFieldDepth(n int) Option {
+	return func(o *options) {
+		o.maxFieldDepth = n
+	}
+}
+
+// limitDepth applies the depth limit of the options to the fields encoded by reflection.
+func (r *root) limitDepth(o *options, fields []zapcore.Field) []zapcore.Field {
+	if o.maxFieldDepth <= 0 {
+		return fields
+	}
+	for i, f := range fields {
+		if f.Type != zapcore.ReflectType {
+			continue
+		}
+		if v, truncated := truncateDepth(reflect.ValueOf(f.Interface), o.maxFieldDepth); truncated {
+			fields[i] = zap.Any(f.Key, v)
+			r.stats.addTruncated(1)
+		}
+	}
+	return fields
+}
+
+// truncateDepth returns a copy of v whose containers nested deeper than depth are
+// replaced with the truncated marker, and reports whether any was.
+func truncateDepth(v reflect.Value, depth int) (interface{}, bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, false
+	}
+	if v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) {
+		return v.Interface(), false
+	}
+
+	switch v.Kind() {
+	case reflect.Map, reflect.Struct, reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Interface(), false
+		}
+		if depth == 0 {
+			return truncatedMarker, true
+		}
+	default:
+		return v.Interface(), false
+	}
+
+	var truncated bool
+	switch v.Kind() {
+	case reflect.Map:
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			value, t := truncateDepth(iter.Value(), depth-1)
+			m[fmt.Sprint(iter.Key().Interface())] = value
+			truncated = truncated || t
+		}
+		return m, truncated
+	case reflect.Struct:
+		m := make(map[string]interface{}, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			key, ok := structFieldKey(v.Type().Field(i))
+			if !ok {
+				continue
+			}
+			value, t := truncateDepth(v.Field(i), depth-1)
+			m[key] = value
+			truncated = truncated || t
+		}
+		return m, truncated
+	default:
+		s := make([]interface{}, v.Len())
+		for i := range s {
+			value, t := truncateDepth(v.Index(i), depth-1)
+			s[i] = value
+			truncated = truncated || t
+		}
+		return s, truncated
+	}
+}
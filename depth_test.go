@@ -0,0 +1,73 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithMaxFieldDepth(t *testing.T) {
+	type inner struct {
+		Tags []string `json:"tags"`
+	}
+	type outer struct {
+		Inner inner     `json:"inner"`
+		At    time.Time `json:"at"`
+	}
+
+	nested := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": map[string]interface{}{
+				"c": map[string]interface{}{
+					"d": map[string]interface{}{"e": 1},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		value         interface{}
+		depth         int
+		expected      string
+		wantTruncated uint64
+	}{
+		"Should truncate a 5-level nested map at depth 2": {
+			value:         nested,
+			depth:         2,
+			expected:      `"payload":{"a":{"b":"...[truncated]"}}`,
+			wantTruncated: 1,
+		},
+		"Should keep a map within the limit as-is": {
+			value:    nested,
+			depth:    5,
+			expected: `"payload":{"a":{"b":{"c":{"d":{"e":1}}}}}`,
+		},
+		"Should truncate structs and slices": {
+			value:         outer{Inner: inner{Tags: []string{"x"}}, At: time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)},
+			depth:         2,
+			expected:      `"payload":{"at":"2021-07-01T00:00:00Z","inner":{"tags":"...[truncated]"}}`,
+			wantTruncated: 1,
+		},
+		"Should not limit the depth by default": {
+			value:    nested,
+			expected: `"payload":{"a":{"b":{"c":{"d":{"e":1}}}}}`,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithMaxFieldDepth(tc.depth))
+
+			ctx, _ := l.With(context.Background())
+			l.Infow(ctx, "something goes here", "payload", tc.value)
+
+			require.Contains(t, buf.String(), tc.expected)
+			require.Equal(t, tc.wantTruncated, l.Stats().Truncated)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package loggy
+
+import (
+	"reflect"
+	"sort"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Diff constructs a field that encodes, under key, the fields that differ between
+// before and after, each as an object with the from and to values, such as
+// {"status":{"from":"open","to":"closed"}}. before and after are structs, maps
+// with string keys, or pointers to them, whose fields are keyed as with
+// WithStructuredSingleArg. A nil before logs every field of after with only its
+// to value, as for a creation, and a nil after every field of before with only
+// its from value, as for a deletion. The difference is computed when Diff is called.
+func Diff(key string, before, after interface{}) zapcore.Field {
+	from, _ := structuredFields(before)
+	to, _ := structuredFields(after)
+	fromValues, toValues := diffValues(from), diffValues(to)
+
+	var changes diffChanges
+	for k, v := range fromValues {
+		w, ok := toValues[k]
+		if ok && reflect.DeepEqual(v, w) {
+			continue
+		}
+		changes = append(changes, diffChange{key: k, from: v, hasFrom: true, to: w, hasTo: ok})
+	}
+	for k, w := range toValues {
+		if _, ok := fromValues[k]; !ok {
+			changes = append(changes, diffChange{key: k, to: w, hasTo: true})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].key < changes[j].key })
+	return zap.Object(key, changes)
+}
+
+// diffValues maps the keys in kv to their values, skipping keys that are not strings.
+func diffValues(kv []interface{}) map[string]interface{} {
+	values := make(map[string]interface{}, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		if k, ok := kv[i].(string); ok {
+			values[k] = kv[i+1]
+		}
+	}
+	return values
+}
+
+type diffChanges []diffChange
+
+func (c diffChanges) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, change := range c {
+		if err := enc.AddObject(change.key, change); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type diffChange struct {
+	key            string
+	from, to       interface{}
+	hasFrom, hasTo bool
+}
+
+func (c diffChange) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if c.hasFrom {
+		if err := enc.AddReflected("from", c.from); err != nil {
+			return err
+		}
+	}
+	if c.hasTo {
+		return enc.AddReflected("to", c.to)
+	}
+	return nil
+}
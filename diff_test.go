@@ -0,0 +1,63 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+type diffTicket struct {
+	ID       int    `json:"id"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee,omitempty"`
+	internal string
+}
+
+func TestDiff(t *testing.T) {
+	tests := map[string]struct {
+		before, after interface{}
+		expected      string
+	}{
+		"Should log only the changed fields": {
+			before:   diffTicket{ID: 7, Status: "open", internal: "a"},
+			after:    &diffTicket{ID: 7, Status: "closed", internal: "b"},
+			expected: `"changes":{"status":{"from":"open","to":"closed"}}`,
+		},
+		"Should log every field of a creation": {
+			after:    diffTicket{ID: 7, Status: "open"},
+			expected: `"changes":{"assignee":{"to":""},"id":{"to":7},"status":{"to":"open"}}`,
+		},
+		"Should log every field of a deletion": {
+			before:   map[string]interface{}{"id": 7},
+			expected: `"changes":{"id":{"from":7}}`,
+		},
+		"Should log keys added and removed between maps": {
+			before:   map[string]interface{}{"id": 7, "tags": []string{"a"}},
+			after:    map[string]interface{}{"id": 7, "owner": "gopher"},
+			expected: `"changes":{"owner":{"to":"gopher"},"tags":{"from":["a"]}}`,
+		},
+		"Should log no change": {
+			before:   diffTicket{ID: 7},
+			after:    diffTicket{ID: 7},
+			expected: `"changes":{}`,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+			l.Infow(context.Background(), "ticket updated", Diff("changes", tc.before, tc.after))
+
+			require.Equal(t, `{"level":"info","msg":"ticket updated",`+tc.expected+"}\n", buf.String())
+		})
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	require.Equal(t, map[string]interface{}{"id": 7}, diffValues([]interface{}{"id", 7, 8, "status"}))
+}
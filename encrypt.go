@@ -0,0 +1,64 @@
+package loggy
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithEncryptedFields encrypts the value of any field whose key is one of keys
+// with encrypt, and logs the ciphertext in base64. String values are encrypted
+// as-is and other values in their JSON form. If encrypt fails, the field is
+// dropped and a warning is logged, so the plaintext is never written. It can be
+// given more than once, each with its own keys and encrypt function; a key given
+// again is encrypted with the function of the last call.
+func WithEncryptedFields(keys []string, encrypt func([]byte) ([]byte, error)) Option {
+	return func(o *options) {
+		if o.encryptedKeys == nil {
+			o.encryptedKeys = make(map[string]func([]byte) ([]byte, error), len(keys))
+		}
+		for _, k := range keys {
+			o.encryptedKeys[k] = encrypt
+		}
+	}
+}
+
+func (c *core) encryptFields(o *options, ent zapcore.Entry, fields []zapcore.Field) []zapcore.Field {
+	if len(o.encryptedKeys) == 0 {
+		return fields
+	}
+	out := fields[:0]
+	for _, f := range fields {
+		encrypt, ok := o.encryptedKeys[f.Key]
+		if !ok {
+			out = append(out, f)
+			continue
+		}
+		ciphertext, err := encryptValue(f, encrypt)
+		if err != nil {
+			c.warn(ent, "loggy: dropped field that failed to encrypt", zap.String("key", f.Key), zap.Error(err))
+			c.root.stats.addDropped(1)
+			continue
+		}
+		out = append(out, zap.String(f.Key, base64.StdEncoding.EncodeToString(ciphertext)))
+	}
+	return out
+}
+
+func encryptValue(f zapcore.Field, encrypt func([]byte) ([]byte, error)) ([]byte, error) {
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+
+	var plaintext []byte
+	if s, ok := enc.Fields[f.Key].(string); ok {
+		plaintext = []byte(s)
+	} else {
+		var err error
+		if plaintext, err = json.Marshal(enc.Fields[f.Key]); err != nil {
+			return nil, err
+		}
+	}
+	return encrypt(plaintext)
+}
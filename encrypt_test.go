@@ -0,0 +1,82 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// reverse stands in for a cipher in tests.
+func reverse(b []byte) ([]byte, error) {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+	return out, nil
+}
+
+func TestWithEncryptedFields(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithEncryptedFields([]string{"ssn", "account"}, reverse))
+
+	ctx, _ := l.With(context.Background(), "ssn", "123-45-6789")
+	l.Infow(ctx, "something goes here", "account", map[string]int{"id": 7}, "key", "value")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+
+	ssn, err := base64.StdEncoding.DecodeString(lines[0]["ssn"].(string))
+	require.NoError(t, err)
+	require.Equal(t, "9876-54-321", string(ssn))
+
+	account, err := base64.StdEncoding.DecodeString(lines[0]["account"].(string))
+	require.NoError(t, err)
+	require.Equal(t, `}7:"di"{`, string(account))
+
+	require.Equal(t, "value", lines[0]["key"])
+	require.NotContains(t, buf.String(), "123-45-6789")
+}
+
+func TestWithEncryptedFields_EncryptionError(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	failing := func([]byte) ([]byte, error) { return nil, errors.New("key unavailable") }
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithEncryptedFields([]string{"ssn"}, failing))
+
+	ctx, _ := l.With(context.Background())
+	l.Infow(ctx, "something goes here", "ssn", "123-45-6789", "key", "value")
+
+	require.Equal(t,
+		`{"level":"warn","msg":"loggy: dropped field that failed to encrypt","key":"ssn","error":"key unavailable"}`+"\n"+
+			`{"level":"info","msg":"something goes here","key":"value"}`+"\n",
+		buf.String(),
+	)
+	require.Equal(t, uint64(1), l.Stats().Dropped)
+}
+
+func TestWithEncryptedFields_MultipleCalls(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	upper := func(b []byte) ([]byte, error) { return bytes.ToUpper(b), nil }
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(),
+		WithEncryptedFields([]string{"ssn"}, reverse),
+		WithEncryptedFields([]string{"account"}, upper),
+	)
+
+	ctx, _ := l.With(context.Background())
+	l.Infow(ctx, "something goes here", "ssn", "123-45-6789", "account", "abc")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+
+	ssn, err := base64.StdEncoding.DecodeString(lines[0]["ssn"].(string))
+	require.NoError(t, err)
+	require.Equal(t, "9876-54-321", string(ssn))
+
+	account, err := base64.StdEncoding.DecodeString(lines[0]["account"].(string))
+	require.NoError(t, err)
+	require.Equal(t, "ABC", string(account))
+}
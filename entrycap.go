@@ -0,0 +1,54 @@
+package loggy
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithPerRequestEntryCap caps the entries below ErrorLevel written during a
+// request to n. A request starts with EntryCapContext, which Middleware and the
+// gRPC interceptors call for every request they serve, and its count is shared by
+// every logger derived from its context. Once the cap is reached, a single warning
+// with the log_capped field is logged and further entries below ErrorLevel are
+// dropped. Entries logged outside of a request are not capped.
+func WithPerRequestEntryCap(n int) Option {
+	return func(o *options) {
+		o.entryCap = n
+	}
+}
+
+// entryCount counts the entries checked against the per-request entry cap.
+type entryCount struct {
+	n int64
+}
+
+// EntryCapContext returns a copy of ctx whose logger counts its entries against
+// the cap set with WithPerRequestEntryCap from zero, independently of every other
+// context, so a request is capped on its own. Middleware and the gRPC
+// interceptors call it at the start of each request. When no cap is set, ctx is
+// returned as is.
+func (l Logger) EntryCapContext(ctx context.Context) context.Context {
+	if l.root == nil || l.root.options().entryCap <= 0 {
+		return ctx
+	}
+	l = l.extractLogger(ctx)
+	newLogger := Logger{s: withCore(l.s, func(c *core) { c.entries = &entryCount{} }), root: l.root}
+	return newLogger.inject(ctx)
+}
+
+// withinCap reports whether ent is written under the per-request entry cap, and
+// logs the warning when it is the first entry over the cap.
+func (c *core) withinCap(ent zapcore.Entry) bool {
+	max := int64(c.root.options().entryCap)
+	if c.entries == nil || max <= 0 || ent.Level >= zapcore.ErrorLevel {
+		return true
+	}
+	n := atomic.AddInt64(&c.entries.n, 1)
+	if n == max+1 {
+		c.warn(ent, "loggy: per-request entry cap reached, dropping entries below error", zap.Bool("log_capped", true), zap.Int64("cap", max))
+	}
+	return n <= max
+}
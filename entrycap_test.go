@@ -0,0 +1,80 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithPerRequestEntryCap(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithPerRequestEntryCap(10))
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	ctx = l.EntryCapContext(ctx)
+	for i := 1; i <= 11; i++ {
+		l.Info(ctx, "entry "+strconv.Itoa(i))
+	}
+	ctx, _ = l.Named(ctx, "app")
+	l.Warn(ctx, "dropped")
+	l.Error(ctx, "still written")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 12)
+	require.Equal(t, "entry 10", lines[9]["msg"])
+	require.Equal(t, "warn", lines[10]["level"])
+	require.Equal(t, true, lines[10]["log_capped"])
+	require.Equal(t, "still written", lines[11]["msg"])
+}
+
+func TestWithPerRequestEntryCap_PerRequest(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithPerRequestEntryCap(1))
+
+	first, _ := l.With(context.Background(), "request_id", "<first>")
+	second, _ := l.With(context.Background(), "request_id", "<second>")
+	first, second = l.EntryCapContext(first), l.EntryCapContext(second)
+	l.Info(first, "written")
+	l.Info(second, "written")
+
+	require.Equal(t,
+		`{"level":"info","msg":"written","request_id":"<first>"}`+"\n"+
+			`{"level":"info","msg":"written","request_id":"<second>"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestWithPerRequestEntryCap_Middleware(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	base := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithPerRequestEntryCap(2))
+	_, app := base.With(context.Background(), "service", "orders")
+
+	handler := app.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		app.Info(r.Context(), "handled")
+	}))
+	for i := 0; i < 5; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+	app.Info(context.Background(), "outside of a request")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 6)
+	for _, line := range lines[:5] {
+		require.Equal(t, "handled", line["msg"])
+		require.Equal(t, "orders", line["service"])
+	}
+	require.Equal(t, "outside of a request", lines[5]["msg"])
+}
+
+func TestLogger_EntryCapContext_Disabled(t *testing.T) {
+	l := New(newZapTestLogger(t, zapcore.AddSync(&bytes.Buffer{})).Sugar())
+
+	ctx := context.Background()
+	require.Equal(t, ctx, l.EntryCapContext(ctx))
+}
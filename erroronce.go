@@ -0,0 +1,49 @@
+package loggy
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// loggederrorsctxkey is the context key of the errors logged by ErrorOnce during a request.
+const loggederrorsctxkey = logContextKey("logged_errors")
+
+// loggedErrors is the set of errors logged by ErrorOnce during a request.
+type loggedErrors struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// add records err and reports whether neither it nor an error it wraps was
+// recorded before.
+func (s *loggedErrors) add(err error) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, logged := range s.errs {
+		if errors.Is(err, logged) || errors.Is(logged, err) {
+			return false
+		}
+	}
+	s.errs = append(s.errs, err)
+	return true
+}
+
+// ErrorOnce logs msg at ErrorLevel with err in the error field and the key/value
+// pairs in args, unless err was already logged with ErrorOnce during the same
+// request, so an error bubbling up through several layers is logged once. Errors
+// are the same when one wraps the other, as errors.Is reports. Requests are
+// tracked by Middleware; with a context it did not create, every error is logged.
+func (l Logger) ErrorOnce(ctx context.Context, err error, msg string, args ...interface{}) {
+	if logged, ok := ctx.Value(loggederrorsctxkey).(*loggedErrors); ok && !logged.add(err) {
+		return
+	}
+	if s, ok := l.sugarAt(ctx, zapcore.ErrorLevel); ok {
+		fields := make([]interface{}, 0, len(args)+1)
+		fields = append(fields, args...)
+		s.Errorw(msg, append(fields, zap.Error(err))...)
+	}
+}
@@ -0,0 +1,60 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_ErrorOnce(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	errNotFound := errors.New("not found")
+	handler := l.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		l.ErrorOnce(ctx, errNotFound, "query failed", "table", "orders")
+		wrapped := fmt.Errorf("loading order: %w", errNotFound)
+		l.ErrorOnce(ctx, wrapped, "handler failed")
+		l.ErrorOnce(ctx, errors.New("timeout"), "handler failed")
+	}))
+
+	for i := 0; i < 2; i++ {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+
+	want := `{"level":"error","msg":"query failed","table":"orders","error":"not found"}` + "\n" +
+		`{"level":"error","msg":"handler failed","error":"timeout"}` + "\n"
+	require.Equal(t, want+want, buf.String())
+}
+
+func TestLogger_ErrorOnce_NoRequest(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	err := errors.New("not found")
+	l.ErrorOnce(context.Background(), err, "query failed")
+	l.ErrorOnce(context.Background(), err, "query failed")
+
+	require.Equal(t, 2, bytes.Count(buf.Bytes(), []byte("\n")))
+}
+
+func TestLogger_ErrorOnce_LeavesArgsUntouched(t *testing.T) {
+	l := New(newZapTestLogger(t, zapcore.AddSync(bytes.NewBuffer([]byte{}))).Sugar())
+
+	args := make([]interface{}, 2, 3)
+	args[0], args[1] = "user_id", 7
+	spare := args[:3]
+	spare[2] = "<spare-value>"
+
+	l.ErrorOnce(context.Background(), errors.New("boom"), "something goes here", args...)
+
+	require.Equal(t, "<spare-value>", spare[2])
+}
@@ -0,0 +1,87 @@
+package loggy
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Errors constructs a field that encodes errs under key as an array of error
+// objects, skipping nil errors. Each object carries the error message in the
+// error field, its verbose form, such as a stack trace, in the errorVerbose
+// field when it has one, and the fields of an error implementing Fielder.
+func Errors(key string, errs []error) zapcore.Field {
+	return zap.Array(key, errorValues(errs))
+}
+
+// ContextError constructs a field that classifies the error of ctx in the
+// ctx_error_type field, as deadline, cancelled or none, so alerts can tell a
+// timeout from a client going away. The error itself, if any, is added in the
+// ctx_error field.
+func ContextError(ctx context.Context) zapcore.Field {
+	return zapcore.Field{Type: zapcore.InlineMarshalerType, Interface: contextErrorValue{err: ctx.Err()}}
+}
+
+type contextErrorValue struct {
+	err error
+}
+
+func (v contextErrorValue) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	switch {
+	case v.err == nil:
+		enc.AddString("ctx_error_type", "none")
+		return nil
+	case errors.Is(v.err, context.DeadlineExceeded):
+		enc.AddString("ctx_error_type", "deadline")
+	default:
+		enc.AddString("ctx_error_type", "cancelled")
+	}
+	enc.AddString("ctx_error", v.err.Error())
+	return nil
+}
+
+type errorValues []error
+
+func (errs errorValues) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if err := enc.AppendObject(errorValue{err}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type errorValue struct {
+	err error
+}
+
+func (e errorValue) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	zap.Error(e.err).AddTo(enc)
+	if f, ok := e.err.(Fielder); ok {
+		addFields(enc, f.Fields())
+	}
+	return nil
+}
+
+// addFields adds key/value pairs, and fields, in the form accepted by the
+// w-methods to enc. A key that is not a string is ignored along with its value.
+func addFields(enc zapcore.ObjectEncoder, args []interface{}) {
+	for i := 0; i < len(args); i++ {
+		if f, ok := args[i].(zapcore.Field); ok {
+			f.AddTo(enc)
+			continue
+		}
+		if i+1 == len(args) {
+			return
+		}
+		if key, ok := args[i].(string); ok {
+			zap.Any(key, args[i+1]).AddTo(enc)
+		}
+		i++
+	}
+}
@@ -0,0 +1,93 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+type verboseError struct{}
+
+func (verboseError) Error() string { return "verbose" }
+
+func (e verboseError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprint(s, "verbose\n<stack>")
+		return
+	}
+	fmt.Fprint(s, e.Error())
+}
+
+type fielderError struct{}
+
+func (fielderError) Error() string { return "with fields" }
+
+func (fielderError) Fields() []interface{} { return []interface{}{"code", 42} }
+
+func TestErrors(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	errs := []error{
+		errors.New("plain"),
+		fmt.Errorf("wrapped: %w", errors.New("plain")),
+		nil,
+		verboseError{},
+		fielderError{},
+	}
+
+	ctx, _ := l.With(context.Background())
+	l.Errorw(ctx, "something goes here", Errors("errors", errs))
+
+	require.Equal(t,
+		`{"level":"error","msg":"something goes here","errors":[`+
+			`{"error":"plain"},`+
+			`{"error":"wrapped: plain"},`+
+			`{"error":"verbose","errorVerbose":"verbose\n<stack>"},`+
+			`{"error":"with fields","code":42}`+
+			`]}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestContextError(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	tests := map[string]struct {
+		ctx      context.Context
+		expected string
+	}{
+		"Should classify a context without error": {
+			ctx:      context.Background(),
+			expected: `{"level":"error","msg":"something goes here","ctx_error_type":"none"}`,
+		},
+		"Should classify a cancelled context": {
+			ctx:      cancelled,
+			expected: `{"level":"error","msg":"something goes here","ctx_error_type":"cancelled","ctx_error":"context canceled"}`,
+		},
+		"Should classify an expired context": {
+			ctx:      expired,
+			expected: `{"level":"error","msg":"something goes here","ctx_error_type":"deadline","ctx_error":"context deadline exceeded"}`,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+			l.Errorw(tc.ctx, "something goes here", ContextError(tc.ctx))
+
+			require.Equal(t, tc.expected+"\n", buf.String())
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package loggy
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Event logs action as the message of a product analytics event at InfoLevel,
+// with category and action in the event_category and event_action fields, the
+// event field set to true, the key/value pairs in args, and the fields of the
+// logger carried by ctx, such as its correlation ID. The event field lets events
+// be routed apart from operational entries. Unlike Audit entries, events go
+// through levels, sampling and limits like any other entry.
+func (l Logger) Event(ctx context.Context, category, action string, args ...interface{}) {
+	s, ok := l.sugarAt(ctx, zapcore.InfoLevel)
+	if !ok {
+		return
+	}
+	fields := make([]interface{}, 0, len(args)+3)
+	fields = append(fields, zap.String("event_category", category), zap.String("event_action", action), zap.Bool("event", true))
+	s.Infow(action, append(fields, args...)...)
+}
@@ -0,0 +1,23 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_Event(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	l.Event(ctx, "checkout", "order_placed", "order_id", 7)
+
+	require.Equal(t,
+		`{"level":"info","msg":"order_placed","request_id":"<request-id-value>","event_category":"checkout","event_action":"order_placed","event":true,"order_id":7}`+"\n",
+		buf.String(),
+	)
+}
@@ -0,0 +1,68 @@
+package loggy
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithContextExtractor registers a function that computes key/value pairs from
+// the context passed at the log site. The pairs are attached to every entry.
+// Extractors run in the order they were registered. An extractor that panics is
+// skipped for that entry and the panic is logged as a warning.
+func WithContextExtractor(extractor func(ctx context.Context) []interface{}) Option {
+	return func(o *options) {
+		o.extractors = append(o.extractors, extractor)
+	}
+}
+
+// LogContext logs msg at level with every field resolvable from ctx: the fields of
+// the logger it carries, those added with AddField, and those computed by the
+// registered extractors. Extractors that find nothing in ctx are skipped. It is
+// meant to dump what is known about a context while triaging an incident.
+func (l Logger) LogContext(ctx context.Context, level zapcore.Level, msg string) {
+	s, ok := l.sugarAt(ctx, level)
+	if !ok {
+		return
+	}
+	// Skip the frame of logw so the caller is the log site.
+	logw(s.Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar(), level, msg)
+}
+
+// extract attaches the fields added to ctx with AddField, and the fields computed
+// by the registered extractors, to s, along with the time elapsed since the
+// request started when WithRequestStart recorded it. It also escalates s under deadline pressure,
+// and hands ctx to the base cores that take it.
+func (r *root) extract(ctx context.Context, s *zap.SugaredLogger) *zap.SugaredLogger {
+	o := r.options()
+	if c, ok := s.Desugar().Core().(*core); ok {
+		if _, ok := c.base.(contextCore); ok {
+			s = withCore(s, func(c *core) { c.ctx = ctx })
+		}
+	}
+	if underDeadlinePressure(o, ctx) {
+		s = withCore(s, func(c *core) { c.escalate = true })
+	}
+	args := contextFields(ctx)
+	if start, ok := ctx.Value(requeststartctxkey).(requestStart); ok && start.logElapsed {
+		args = append(args, elapsedSinceStartKey, now().Sub(start.time))
+	}
+	for _, extractor := range o.extractors {
+		args = append(args, runExtractor(ctx, s, extractor)...)
+	}
+	if len(args) == 0 {
+		return s
+	}
+	return s.With(args...)
+}
+
+func runExtractor(ctx context.Context, s *zap.SugaredLogger, extractor func(ctx context.Context) []interface{}) (args []interface{}) {
+	defer func() {
+		if v := recover(); v != nil {
+			s.Warnw("loggy: context extractor panicked", "panic", v)
+			args = nil
+		}
+	}()
+	return extractor(ctx)
+}
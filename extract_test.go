@@ -0,0 +1,137 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+type tenantKey struct{}
+
+type tenant struct {
+	ID   string
+	Plan string
+}
+
+func TestWithContextExtractor(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(),
+		WithContextExtractor(func(ctx context.Context) []interface{} {
+			tn, ok := ctx.Value(tenantKey{}).(tenant)
+			if !ok {
+				return nil
+			}
+			return []interface{}{"tenant_id", tn.ID, "tenant_plan", tn.Plan}
+		}),
+		WithContextExtractor(func(ctx context.Context) []interface{} {
+			return []interface{}{"region", "<region-value>"}
+		}),
+	)
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, tenant{ID: "<tenant-id-value>", Plan: "<plan-value>"})
+	ctx, _ = l.With(ctx, "request_id", "<request-id-value>")
+	l.Infow(ctx, "something goes here", "key", "value")
+	l.Info(context.Background(), "no tenant")
+
+	require.Equal(t,
+		`{"level":"info","msg":"something goes here","request_id":"<request-id-value>","tenant_id":"<tenant-id-value>","tenant_plan":"<plan-value>","region":"<region-value>","key":"value"}`+"\n"+
+			`{"level":"info","msg":"no tenant","region":"<region-value>"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestWithContextExtractor_SkippedForDisabledLevels(t *testing.T) {
+	tests := map[string]struct {
+		log      func(ctx context.Context, l Logger)
+		expected int
+	}{
+		"Should not run extractors for a disabled Debug": {
+			log: func(ctx context.Context, l Logger) {
+				l.Debug(ctx, "debug")
+				l.Debugf(ctx, "debug %d", 1)
+				l.Debugw(ctx, "debug", "key", "value")
+			},
+		},
+		"Should not run extractors for a disabled level of LogContext and LogAt": {
+			log: func(ctx context.Context, l Logger) {
+				l.LogContext(ctx, zapcore.DebugLevel, "debug")
+				l.LogAt(ctx, time.Time{}, zapcore.DebugLevel, "debug")
+				l.DumpGoroutines(ctx, zapcore.DebugLevel)
+			},
+		},
+		"Should run extractors for an enabled level": {
+			log: func(ctx context.Context, l Logger) {
+				l.Info(ctx, "info")
+				l.Event(ctx, "<category-value>", "<action-value>")
+			},
+			expected: 2,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			l := New(newZapTestLogger(t, zapcore.AddSync(bytes.NewBuffer([]byte{}))).Sugar(),
+				WithLevel(zapcore.InfoLevel),
+				WithContextExtractor(func(ctx context.Context) []interface{} {
+					calls++
+					return nil
+				}),
+			)
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+
+			tc.log(ctx, l)
+
+			require.Equal(t, tc.expected, calls)
+		})
+	}
+}
+
+func TestWithContextExtractor_RecoversPanics(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(),
+		WithContextExtractor(func(ctx context.Context) []interface{} {
+			panic("boom")
+		}),
+		WithContextExtractor(func(ctx context.Context) []interface{} {
+			return []interface{}{"region", "<region-value>"}
+		}),
+	)
+
+	require.NotPanics(t, func() {
+		l.Info(context.Background(), "something goes here")
+	})
+	require.Equal(t,
+		`{"level":"warn","msg":"loggy: context extractor panicked","panic":"boom"}`+"\n"+
+			`{"level":"info","msg":"something goes here","region":"<region-value>"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestLogger_LogContext(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(),
+		WithCorrelationSources(CorrelationFromContextKey(correlationKey{})),
+		WithContextExtractor(func(ctx context.Context) []interface{} {
+			if tn, ok := ctx.Value(tenantKey{}).(tenant); ok {
+				return []interface{}{"tenant_id", tn.ID}
+			}
+			return nil
+		}),
+	)
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	ctx = context.WithValue(ctx, correlationKey{}, "<correlation-id-value>")
+	ctx = AddField(ctx, "step", 1)
+	// The tenant is not in ctx, so its extractor contributes nothing.
+	l.LogContext(ctx, zapcore.WarnLevel, "context dump")
+
+	require.Equal(t,
+		`{"level":"warn","msg":"context dump","request_id":"<request-id-value>","step":1,"correlation_id":"<correlation-id-value>"}`+"\n",
+		buf.String(),
+	)
+}
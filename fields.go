@@ -0,0 +1,69 @@
+package loggy
+
+import (
+	"sort"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Strings constructs a field that encodes vs under key as a JSON array.
+// It mirrors zap.Strings, so typed fields can be mixed with key/value pairs
+// at the log site.
+func Strings(key string, vs []string) zapcore.Field {
+	return zap.Strings(key, vs)
+}
+
+// Ints constructs a field that encodes vs under key as a JSON array.
+// It mirrors zap.Ints.
+func Ints(key string, vs []int) zapcore.Field {
+	return zap.Ints(key, vs)
+}
+
+// Any constructs a field that encodes v under key with the most efficient
+// encoder for its type. On top of the types zap.Any encodes without reflection,
+// such as []string and []int, it handles maps with string keys and string, int
+// or interface{} values, which zap encodes with reflection. Their keys are
+// encoded in sorted order, as encoding/json does, so the output is stable.
+// Other values fall back to reflection.
+func Any(key string, v interface{}) zapcore.Field {
+	switch v := v.(type) {
+	case map[string]string:
+		return zap.Object(key, zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				enc.AddString(k, v[k])
+			}
+			return nil
+		}))
+	case map[string]int:
+		return zap.Object(key, zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				enc.AddInt(k, v[k])
+			}
+			return nil
+		}))
+	case map[string]interface{}:
+		return zap.Object(key, zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				Any(k, v[k]).AddTo(enc)
+			}
+			return nil
+		}))
+	}
+	return zap.Any(key, v)
+}
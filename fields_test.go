@@ -0,0 +1,104 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFieldHelpers(t *testing.T) {
+	tests := map[string]struct {
+		field    zapcore.Field
+		expected string
+	}{
+		"Should encode strings":              {field: Strings("tags", []string{"a", "b"}), expected: `"tags":["a","b"]`},
+		"Should encode empty strings":        {field: Strings("tags", nil), expected: `"tags":[]`},
+		"Should encode ints":                 {field: Ints("ids", []int{1, 2}), expected: `"ids":[1,2]`},
+		"Should encode a slice with Any":     {field: Any("tags", []string{"a"}), expected: `"tags":["a"]`},
+		"Should encode a string map":         {field: Any("labels", map[string]string{"env": "prod"}), expected: `"labels":{"env":"prod"}`},
+		"Should encode an int map":           {field: Any("counts", map[string]int{"hits": 3}), expected: `"counts":{"hits":3}`},
+		"Should encode a nested map":         {field: Any("m", map[string]interface{}{"ids": []int{1}}), expected: `"m":{"ids":[1]}`},
+		"Should encode a scalar with Any":    {field: Any("n", 7), expected: `"n":7`},
+		"Should fall back to reflection":     {field: Any("s", struct{ A int }{A: 1}), expected: `"s":{"A":1}`},
+		"Should encode a nil value with Any": {field: Any("v", nil), expected: `"v":null`},
+		"Should sort the keys of a string map": {
+			field:    Any("labels", map[string]string{"region": "eu", "env": "prod", "team": "core"}),
+			expected: `"labels":{"env":"prod","region":"eu","team":"core"}`,
+		},
+		"Should sort the keys of an int map": {
+			field:    Any("counts", map[string]int{"misses": 1, "hits": 3, "errors": 2}),
+			expected: `"counts":{"errors":2,"hits":3,"misses":1}`,
+		},
+		"Should sort the keys of a nested map": {
+			field:    Any("m", map[string]interface{}{"b": 2, "a": map[string]int{"y": 1, "x": 2}, "c": "3"}),
+			expected: `"m":{"a":{"x":2,"y":1},"b":2,"c":"3"}`,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+			l.Infow(context.Background(), "something goes here", tc.field)
+
+			require.Equal(t, `{"level":"info","msg":"something goes here",`+tc.expected+"}\n", buf.String())
+		})
+	}
+}
+
+func BenchmarkLoggy_FieldHelpers(b *testing.B) {
+	tags := []string{"alpha", "beta", "gamma", "delta"}
+	counts := map[string]int{"alpha": 1, "beta": 2}
+	newLogger := func() Logger {
+		encoderCfg := zapcore.EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: zapcore.LowercaseLevelEncoder}
+		return New(zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderCfg), zapcore.AddSync(discard{}), zap.DebugLevel)).Sugar())
+	}
+
+	b.Run("Strings", func(b *testing.B) {
+		l := newLogger()
+		ctx, _ := l.With(context.Background())
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			l.Infow(ctx, "something goes here", Strings("tags", tags))
+		}
+	})
+	b.Run("RawSlice", func(b *testing.B) {
+		l := newLogger()
+		ctx, _ := l.With(context.Background())
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			l.Infow(ctx, "something goes here", "tags", tags)
+		}
+	})
+	b.Run("AnyMap", func(b *testing.B) {
+		l := newLogger()
+		ctx, _ := l.With(context.Background())
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			l.Infow(ctx, "something goes here", Any("counts", counts))
+		}
+	})
+	b.Run("RawMap", func(b *testing.B) {
+		l := newLogger()
+		ctx, _ := l.With(context.Background())
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			l.Infow(ctx, "something goes here", "counts", counts)
+		}
+	})
+}
+
+// discard is a zapcore.WriteSyncer dropping everything, so benchmarks measure encoding.
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
+func (discard) Sync() error                 { return nil }
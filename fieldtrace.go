@@ -0,0 +1,39 @@
+package loggy
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithFieldChangeTracing logs a DebugLevel entry with the fields_added field
+// listing the keys every call to With adds. It is a development aid to find out
+// why a field does or does not appear on an entry. Nothing is computed while
+// DebugLevel is disabled.
+func WithFieldChangeTracing() Option {
+	return func(o *options) {
+		o.fieldChangeTracing = true
+	}
+}
+
+// traceFieldChange logs the keys of args, which are about to be added to s.
+func (r *root) traceFieldChange(s *zap.SugaredLogger, args []interface{}) {
+	if !r.options().fieldChangeTracing || len(args) == 0 || !s.Desugar().Core().Enabled(zapcore.DebugLevel) {
+		return
+	}
+	s.Debugw("fields added", "fields_added", argKeys(args))
+}
+
+// argKeys returns the keys of the loosely-typed key/value pairs accepted by the sugared API.
+func argKeys(args []interface{}) []string {
+	keys := make([]string, 0, len(args)/2+1)
+	for i := 0; i < len(args); i++ {
+		switch arg := args[i].(type) {
+		case zapcore.Field:
+			keys = append(keys, arg.Key)
+		case string:
+			keys = append(keys, arg)
+			i++
+		}
+	}
+	return keys
+}
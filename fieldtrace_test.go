@@ -0,0 +1,40 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithFieldChangeTracing(t *testing.T) {
+	tests := map[string]struct {
+		level zapcore.Level
+		want  string
+	}{
+		"Should log the added keys when debug is enabled": {
+			level: zapcore.DebugLevel,
+			want: `{"level":"debug","msg":"fields added","fields_added":["request_id","tenant_id"]}` + "\n" +
+				`{"level":"info","msg":"something goes here","request_id":"<request-id-value>","tenant_id":"<tenant-id-value>"}` + "\n",
+		},
+		"Should not log the added keys when debug is disabled": {
+			level: zapcore.InfoLevel,
+			want:  `{"level":"info","msg":"something goes here","request_id":"<request-id-value>","tenant_id":"<tenant-id-value>"}` + "\n",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithLevel(tc.level), WithFieldChangeTracing())
+
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>", zap.String("tenant_id", "<tenant-id-value>"))
+			l.Info(ctx, "something goes here")
+
+			require.Equal(t, tc.want, buf.String())
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package loggy
+
+import (
+	"bytes"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithLineFraming wraps every line written by the constructors of this package,
+// such as NewProduction, between prefix and suffix, for log shippers that need
+// explicit markers. The encoder's line ending is kept after the suffix, so each
+// line is written as prefix, the encoded entry, suffix and the line ending.
+func WithLineFraming(prefix, suffix string) Option {
+	return func(o *options) {
+		o.linePrefix = prefix
+		o.lineSuffix = suffix
+	}
+}
+
+// framingWriter wraps each line written to it between a prefix and a suffix.
+// Encoders write one entry per call, so each call is treated as one line.
+type framingWriter struct {
+	zapcore.WriteSyncer
+	prefix     string
+	suffix     string
+	lineEnding string
+}
+
+func (w framingWriter) Write(p []byte) (int, error) {
+	line := bytes.TrimSuffix(p, []byte(w.lineEnding))
+
+	framed := make([]byte, 0, len(w.prefix)+len(line)+len(w.suffix)+len(w.lineEnding))
+	framed = append(framed, w.prefix...)
+	framed = append(framed, line...)
+	framed = append(framed, w.suffix...)
+	framed = append(framed, w.lineEnding...)
+
+	if _, err := w.WriteSyncer.Write(framed); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
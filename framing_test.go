@@ -0,0 +1,45 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithLineFraming(t *testing.T) {
+	tests := map[string]struct {
+		lineEnding string
+		want       string
+	}{
+		"Should frame a line with the default line ending": {
+			want: `<<<{"level":"info","msg":"something goes here","request_id":"<request-id-value>"}>>>` + "\n",
+		},
+		"Should frame a line with a custom line ending": {
+			lineEnding: "\r\n",
+			want:       `<<<{"level":"info","msg":"something goes here","request_id":"<request-id-value>"}>>>` + "\r\n",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := NewProduction(zapcore.AddSync(buf), zapcore.InfoLevel,
+				WithEncoderConfig(zapcore.EncoderConfig{
+					MessageKey:  "msg",
+					LevelKey:    "level",
+					EncodeLevel: zapcore.LowercaseLevelEncoder,
+					LineEnding:  tc.lineEnding,
+				}),
+				WithLineFraming("<<<", ">>>"),
+			)
+
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+			l.Info(ctx, "something goes here")
+
+			require.Equal(t, tc.want, buf.String())
+		})
+	}
+}
@@ -3,6 +3,11 @@ module github.com/ahmedalhulaibi/loggy
 go 1.16
 
 require (
-	github.com/stretchr/testify v1.7.0
+	github.com/coreos/go-systemd/v22 v22.5.0
+	github.com/stretchr/testify v1.7.1
+	go.opentelemetry.io/otel/trace v1.7.0
+	go.uber.org/multierr v1.6.0
 	go.uber.org/zap v1.18.1
 )
+
+require golang.org/x/tools v0.21.1-0.20240508182429-e35e4ccd0d2d // indirect
@@ -0,0 +1,35 @@
+package loggy
+
+import (
+	"context"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// goroutineStacks is swapped in tests to observe whether the stacks were captured.
+var goroutineStacks = allGoroutineStacks
+
+// DumpGoroutines logs the stack of every goroutine at level in the goroutines field.
+// Capturing the stacks is expensive, so nothing is captured when level is disabled.
+// It is intended to be wired to a signal handler or a debug endpoint to investigate hangs.
+func (l Logger) DumpGoroutines(ctx context.Context, level zapcore.Level) {
+	s, ok := l.sugarAt(ctx, level)
+	if !ok {
+		return
+	}
+	// Skip the frame of logw so the caller is the log site.
+	logw(s.Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar(), level, "goroutine dump", "goroutines", goroutineStacks())
+}
+
+func allGoroutineStacks() string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -0,0 +1,47 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_DumpGoroutines(t *testing.T) {
+	captured := 0
+	goroutineStacks = func() string {
+		captured++
+		return allGoroutineStacks()
+	}
+	t.Cleanup(func() { goroutineStacks = allGoroutineStacks })
+
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithLevel(zapcore.InfoLevel))
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+
+	l.DumpGoroutines(ctx, zapcore.DebugLevel)
+	require.Empty(t, buf.String())
+	require.Equal(t, 0, captured)
+
+	l.DumpGoroutines(ctx, zapcore.InfoLevel)
+	require.Equal(t, 1, captured)
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+	require.Equal(t, "info", lines[0]["level"])
+	require.Equal(t, "<request-id-value>", lines[0]["request_id"])
+	require.Contains(t, lines[0]["goroutines"], "goroutine ")
+	require.Contains(t, lines[0]["goroutines"], "TestLogger_DumpGoroutines")
+}
+
+func TestLogger_DumpGoroutines_Caller(t *testing.T) {
+	l, logs := newCallerTestLogger(t)
+
+	l.DumpGoroutines(context.Background(), zapcore.InfoLevel)
+	_, file, line, _ := runtime.Caller(0)
+
+	requireCaller(t, logs, file, line-1)
+}
@@ -0,0 +1,42 @@
+// Package grpcinterceptor integrates loggy with gRPC servers. It lives in its own
+// module so that only the programs using it depend on gRPC.
+package grpcinterceptor
+
+import (
+	"context"
+
+	"github.com/ahmedalhulaibi/loggy"
+	"google.golang.org/grpc/peer"
+)
+
+// Field keys of the gRPC context extractors.
+const (
+	peerKey        = "peer_address"
+	authSubjectKey = "auth_subject"
+)
+
+// WithPeer attaches the address of the gRPC peer carried by the context, as set
+// by the gRPC server for every call, as the peer_address field.
+func WithPeer() loggy.Option {
+	return loggy.WithContextExtractor(func(ctx context.Context) []interface{} {
+		p, ok := peer.FromContext(ctx)
+		if !ok || p.Addr == nil {
+			return nil
+		}
+		return []interface{}{peerKey, p.Addr.String()}
+	})
+}
+
+// WithAuthSubject attaches the authenticated subject found by extract as the
+// auth_subject field. How the subject is stored depends on the authentication
+// middleware, so it is looked up by extract, which reports false when the
+// context does not carry one.
+func WithAuthSubject(extract func(ctx context.Context) (string, bool)) loggy.Option {
+	return loggy.WithContextExtractor(func(ctx context.Context) []interface{} {
+		subject, ok := extract(ctx)
+		if !ok {
+			return nil
+		}
+		return []interface{}{authSubjectKey, subject}
+	})
+}
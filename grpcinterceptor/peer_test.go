@@ -0,0 +1,61 @@
+package grpcinterceptor
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/ahmedalhulaibi/loggy"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc/peer"
+)
+
+type subjectKey struct{}
+
+func newLogger(buf *bytes.Buffer, opts ...loggy.Option) loggy.Logger {
+	encoderCfg := zapcore.EncoderConfig{
+		MessageKey:  "msg",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	}
+	return loggy.New(zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderCfg), zapcore.AddSync(buf), zap.DebugLevel)).Sugar(), opts...)
+}
+
+func TestWithPeerAndAuthSubject(t *testing.T) {
+	subject := func(ctx context.Context) (string, bool) {
+		s, ok := ctx.Value(subjectKey{}).(string)
+		return s, ok
+	}
+
+	tests := map[string]struct {
+		ctx      context.Context
+		expected string
+	}{
+		"Should attach the peer address and the subject": {
+			ctx: context.WithValue(
+				peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.42"), Port: 50051}}),
+				subjectKey{}, "<subject-value>",
+			),
+			expected: `{"level":"info","msg":"handled","peer_address":"203.0.113.42:50051","auth_subject":"<subject-value>"}`,
+		},
+		"Should omit the fields outside of a gRPC call": {
+			ctx:      context.Background(),
+			expected: `{"level":"info","msg":"handled"}`,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := newLogger(buf, WithPeer(), WithAuthSubject(subject))
+
+			ctx, _ := l.With(tc.ctx)
+			l.Info(ctx, "handled")
+
+			require.Equal(t, tc.expected+"\n", buf.String())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package grpcinterceptor
+
+import (
+	"context"
+	"time"
+
+	"github.com/ahmedalhulaibi/loggy"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// methodKey is the field key of the full name of the gRPC method being served.
+const methodKey = "grpc_method"
+
+// StreamServerInterceptor returns a gRPC interceptor that injects a child of l,
+// carrying the grpc_method field, into the context of every server stream, so
+// handlers logging with stream.Context() get the fields of the call. It logs when
+// the stream opens, and when it closes with the duration field, at ErrorLevel with
+// the error field if the handler failed.
+func StreamServerInterceptor(l loggy.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx, logger := l.With(ss.Context(), methodKey, info.FullMethod)
+		ctx = l.EntryCapContext(ctx)
+		logger.Info(ctx, "stream opened")
+
+		err := handler(srv, &loggedServerStream{ServerStream: ss, ctx: ctx})
+
+		if err != nil {
+			logger.Errorw(ctx, "stream closed", "duration", time.Since(start), zap.Error(err))
+		} else {
+			logger.Infow(ctx, "stream closed", "duration", time.Since(start))
+		}
+		return err
+	}
+}
+
+// loggedServerStream is a grpc.ServerStream whose context carries a logger.
+type loggedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *loggedServerStream) Context() context.Context {
+	return s.ctx
+}
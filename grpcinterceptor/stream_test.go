@@ -0,0 +1,67 @@
+package grpcinterceptor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeServerStream is a grpc.ServerStream that only provides a context.
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	tests := map[string]struct {
+		err           error
+		expectedLevel string
+	}{
+		"Should log a stream that succeeds": {expectedLevel: "info"},
+		"Should log a stream that fails":    {err: errors.New("boom"), expectedLevel: "error"},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := newLogger(buf)
+
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+			info := &grpc.StreamServerInfo{FullMethod: "/orders.Orders/Watch"}
+			handler := func(srv interface{}, stream grpc.ServerStream) error {
+				l.Info(stream.Context(), "streaming")
+				return tc.err
+			}
+
+			err := StreamServerInterceptor(l)(nil, fakeServerStream{ctx: ctx}, info, handler)
+			require.Equal(t, tc.err, err)
+
+			var lines []map[string]interface{}
+			for _, raw := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+				line := map[string]interface{}{}
+				require.NoError(t, json.Unmarshal([]byte(raw), &line))
+				lines = append(lines, line)
+			}
+			require.Len(t, lines, 3)
+			for _, line := range lines {
+				require.Equal(t, "<request-id-value>", line["request_id"])
+				require.Equal(t, "/orders.Orders/Watch", line["grpc_method"])
+			}
+			require.Equal(t, "stream opened", lines[0]["msg"])
+			require.Equal(t, "streaming", lines[1]["msg"])
+			require.Equal(t, "stream closed", lines[2]["msg"])
+			require.Equal(t, tc.expectedLevel, lines[2]["level"])
+			require.Contains(t, lines[2], "duration")
+		})
+	}
+}
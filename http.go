@@ -0,0 +1,176 @@
+package loggy
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// correlationIDKey is the field key of the correlation ID attached by the HTTP middleware.
+const correlationIDKey = "correlation_id"
+
+// requestctxkey is the context key of the request served by the HTTP middleware.
+const requestctxkey = logContextKey("request")
+
+// requeststartctxkey is the context key of the requestStart of a request.
+const requeststartctxkey = logContextKey("request_start")
+
+// requestStart is the time a request started: when the HTTP middleware started
+// serving it, or when a logger was first injected into its context.
+type requestStart struct {
+	time time.Time
+	// logElapsed attaches the time elapsed since then to every entry, as set by WithRequestStart.
+	logElapsed bool
+}
+
+// elapsedSinceStartKey is the field key of the time elapsed since the request started.
+const elapsedSinceStartKey = "elapsed_since_start"
+
+// MiddlewareOption configures the HTTP middleware returned by Logger.Middleware.
+type MiddlewareOption func(*middlewareOptions)
+
+type middlewareOptions struct {
+	// correlationHeader is the header carrying the correlation ID. Empty disables correlation.
+	correlationHeader string
+	// newCorrelationID generates a correlation ID when the request has none.
+	newCorrelationID func() string
+	// requestStart records the time each request started.
+	requestStart bool
+	// debugSampleRate is the fraction of requests logged at DebugLevel, drawn with debugSampleGen.
+	debugSampleRate float64
+	debugSampleGen  func() float64
+}
+
+// WithCorrelationID makes the middleware attach the correlation ID of each request,
+// read from header, as the correlation_id field. When the request does not carry
+// one, it is generated with gen. If gen is nil, the generator set with
+// WithIDGenerator is used, or UUIDv4 by default.
+// The correlation ID is echoed back in the same response header.
+func WithCorrelationID(header string, gen func() string) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.correlationHeader = header
+		if gen != nil {
+			o.newCorrelationID = gen
+		}
+	}
+}
+
+// WithIDGenerator sets the function generating the correlation ID of requests
+// that do not carry one, such as UUIDv4, UUIDv7 or a KSUID generator. It is
+// called concurrently, so it must be safe for concurrent use.
+func WithIDGenerator(gen func() string) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.newCorrelationID = gen
+	}
+}
+
+// WithRequestStart makes the middleware record the time each request started,
+// so every entry logged with its context carries the elapsed_since_start field,
+// the time elapsed since then, computed when the entry is logged.
+func WithRequestStart() MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.requestStart = true
+	}
+}
+
+// WithDebugSampleRate makes the middleware log a rate fraction of requests, such
+// as 0.01 for 1%, at DebugLevel, as if with OverrideLevel, and the others at the
+// level configured for l. A request is picked when gen returns a number below
+// rate; gen should return numbers in [0, 1), and defaults to rand.Float64 when nil.
+// The decision is made once per request, when it starts.
+func WithDebugSampleRate(rate float64, gen func() float64) MiddlewareOption {
+	return func(o *middlewareOptions) {
+		o.debugSampleRate = rate
+		o.debugSampleGen = gen
+		if o.debugSampleGen == nil {
+			o.debugSampleGen = rand.Float64
+		}
+	}
+}
+
+// Middleware returns HTTP middleware that injects a child of l into the context
+// of every request, so handlers log with the request's fields.
+func (l Logger) Middleware(opts ...MiddlewareOption) func(http.Handler) http.Handler {
+	o := &middlewareOptions{newCorrelationID: UUIDv4}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var args []interface{}
+			if o.correlationHeader != "" {
+				id := r.Header.Get(o.correlationHeader)
+				if id == "" {
+					id = o.newCorrelationID()
+				}
+				w.Header().Set(o.correlationHeader, id)
+				args = append(args, correlationIDKey, id)
+			}
+
+			ctx := context.WithValue(r.Context(), requestctxkey, r)
+			ctx = context.WithValue(ctx, loggederrorsctxkey, &loggedErrors{})
+			if o.requestStart {
+				ctx = context.WithValue(ctx, requeststartctxkey, requestStart{time: now(), logElapsed: true})
+			}
+			ctx, _ = l.With(ctx, args...)
+			ctx = l.EntryCapContext(ctx)
+			if o.debugSampleRate > 0 && o.debugSampleGen() < o.debugSampleRate {
+				ctx, _ = l.OverrideLevel(ctx, zapcore.DebugLevel)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RecoveryMiddleware returns HTTP middleware that recovers a panic in next, logs
+// it at ErrorLevel like Recover, with the method and path of the request and the
+// fields of the logger carried by its context, and responds with a 500 status.
+// A panic with http.ErrAbortHandler is propagated, so net/http aborts the
+// response as intended.
+func (l Logger) RecoveryMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+			if s, ok := l.sugarAt(r.Context(), zapcore.ErrorLevel); ok {
+				args := append([]interface{}{"method", r.Method, "path", r.URL.Path}, panicFields(v, debug.Stack())...)
+				logRecovered(s, args)
+			}
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// StatusClass constructs a field that encodes the HTTP status code under key,
+// and its class, such as 5xx, under key+"_class", for dashboards bucketing
+// responses by class. Codes outside of 100 to 599 are of the unknown class.
+func StatusClass(key string, code int) zapcore.Field {
+	return zapcore.Field{Key: key, Type: zapcore.InlineMarshalerType, Interface: statusClassValue{key: key, code: code}}
+}
+
+type statusClassValue struct {
+	key  string
+	code int
+}
+
+func (v statusClassValue) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt(v.key, v.code)
+	class := "unknown"
+	if v.code >= 100 && v.code < 600 {
+		class = strconv.Itoa(v.code/100) + "xx"
+	}
+	enc.AddString(v.key+"_class", class)
+	return nil
+}
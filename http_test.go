@@ -0,0 +1,227 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_Middleware_WithCorrelationID(t *testing.T) {
+	tests := map[string]struct {
+		requestHeader string
+		gen           func() string
+		want          string
+	}{
+		"Should use the correlation ID from the request header": {
+			requestHeader: "<correlation-id-value>",
+			gen:           func() string { return "<generated-value>" },
+			want:          "<correlation-id-value>",
+		},
+		"Should generate a correlation ID when the header is absent": {
+			gen:  func() string { return "<generated-value>" },
+			want: "<generated-value>",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+			handler := l.Middleware(WithCorrelationID("X-Correlation-ID", tc.gen))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				l.Info(r.Context(), "handled")
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.requestHeader != "" {
+				req.Header.Set("X-Correlation-ID", tc.requestHeader)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			require.Equal(t, tc.want, rec.Header().Get("X-Correlation-ID"))
+			require.Equal(t, `{"level":"info","msg":"handled","correlation_id":"`+tc.want+`"}`+"\n", buf.String())
+		})
+	}
+}
+
+func TestLogger_Middleware_DefaultsToUUID(t *testing.T) {
+	l := New(newZapTestLogger(t, zapcore.AddSync(bytes.NewBuffer([]byte{}))).Sugar())
+
+	handler := l.Middleware(WithCorrelationID("X-Correlation-ID", nil))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	require.Regexp(t, uuid, rec.Header().Get("X-Correlation-ID"))
+}
+
+func TestLogger_Middleware_WithIDGenerator(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	var n int64
+	gen := func() string { return "<id-" + strconv.FormatInt(atomic.AddInt64(&n, 1), 10) + ">" }
+	handler := l.Middleware(WithIDGenerator(gen), WithCorrelationID("X-Correlation-ID", nil))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		l.Info(r.Context(), "handled")
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.Equal(t,
+		`{"level":"info","msg":"handled","correlation_id":"<id-1>"}`+"\n"+
+			`{"level":"info","msg":"handled","correlation_id":"<id-2>"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestStatusClass(t *testing.T) {
+	tests := map[string]struct {
+		code     int
+		expected string
+	}{
+		"Should classify a success":                       {code: 200, expected: `"status":200,"status_class":"2xx"`},
+		"Should classify a client error":                  {code: 404, expected: `"status":404,"status_class":"4xx"`},
+		"Should classify a server error":                  {code: 503, expected: `"status":503,"status_class":"5xx"`},
+		"Should classify an out-of-range code as unknown": {code: 999, expected: `"status":999,"status_class":"unknown"`},
+		"Should classify a negative code as unknown":      {code: -1, expected: `"status":-1,"status_class":"unknown"`},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+			l.Infow(context.Background(), "handled", StatusClass("status", tc.code))
+
+			require.Equal(t, `{"level":"info","msg":"handled",`+tc.expected+"}\n", buf.String())
+		})
+	}
+}
+
+func TestLogger_RecoveryMiddleware(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	gen := func() string { return "<correlation-id-value>" }
+	handler := l.Middleware(WithCorrelationID("X-Correlation-ID", gen))(l.RecoveryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders", nil))
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+	require.Equal(t, "error", lines[0]["level"])
+	require.Equal(t, "recovered from panic", lines[0]["msg"])
+	require.Equal(t, "GET", lines[0]["method"])
+	require.Equal(t, "/orders", lines[0]["path"])
+	require.Equal(t, "boom", lines[0]["panic"])
+	require.Equal(t, "<correlation-id-value>", lines[0]["correlation_id"])
+	require.Contains(t, lines[0]["stack"], "runtime/debug.Stack")
+}
+
+func TestLogger_RecoveryMiddleware_Caller(t *testing.T) {
+	l, logs := newCallerTestLogger(t)
+
+	var file string
+	var line int
+	handler := l.RecoveryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, file, line, _ = runtime.Caller(0)
+		panic("boom")
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	requireCaller(t, logs, file, line+1)
+}
+
+func TestLogger_RecoveryMiddleware_ErrAbortHandler(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	handler := l.RecoveryMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	require.Empty(t, buf.String())
+}
+
+func TestLogger_Middleware_WithRequestStart(t *testing.T) {
+	start := time.Now()
+	t.Cleanup(func() { now = time.Now })
+	now = func() time.Time { return start }
+
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	handler := l.Middleware(WithRequestStart())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		now = func() time.Time { return start.Add(5 * time.Millisecond) }
+		l.Info(r.Context(), "first")
+		now = func() time.Time { return start.Add(15 * time.Millisecond) }
+		l.Info(r.Context(), "second")
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	l.Info(context.Background(), "outside of a request")
+
+	require.Equal(t,
+		`{"level":"info","msg":"first","elapsed_since_start":"5ms"}`+"\n"+
+			`{"level":"info","msg":"second","elapsed_since_start":"15ms"}`+"\n"+
+			`{"level":"info","msg":"outside of a request"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestLogger_Middleware_WithDebugSampleRate(t *testing.T) {
+	tests := map[string]struct {
+		rate     float64
+		expected string
+	}{
+		"Should log every request at debug level": {
+			rate:     1,
+			expected: `{"level":"debug","msg":"debug"}` + "\n" + `{"level":"info","msg":"info"}` + "\n",
+		},
+		"Should log no request at debug level": {
+			rate:     0,
+			expected: `{"level":"info","msg":"info"}` + "\n",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := NewProduction(zapcore.AddSync(buf), zapcore.InfoLevel, bufferedTestConfig())
+
+			// The generator returns numbers just below 1, and 0 alike.
+			draws := []float64{0.999, 0}
+			gen := func() float64 {
+				d := draws[0]
+				draws = draws[1:]
+				return d
+			}
+			handler := l.Middleware(WithDebugSampleRate(tc.rate, gen))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				l.Debug(r.Context(), "debug")
+				l.Info(r.Context(), "info")
+			}))
+			for i := 0; i < 2; i++ {
+				buf.Reset()
+				handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+				require.Equal(t, tc.expected, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package loggy
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	mrand "math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// UUIDv4 returns a random (version 4) UUID. It is the default correlation ID
+// generator of the HTTP middleware.
+func UUIDv4() string {
+	var b [16]byte
+	readRandom(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return formatUUID(b)
+}
+
+// UUIDv7 returns a time-ordered (version 7) UUID, whose first 48 bits are the
+// current Unix time in milliseconds, so IDs sort by creation time.
+func UUIDv7() string {
+	var b [16]byte
+	readRandom(b[6:])
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(time.Now().UnixNano()/int64(time.Millisecond)))
+	copy(b[0:6], ms[2:8])
+	b[6] = (b[6] & 0x0f) | 0x70
+	b[8] = (b[8] & 0x3f) | 0x80
+	return formatUUID(b)
+}
+
+// randRead reads cryptographically random bytes. It is swapped in tests to
+// simulate crypto/rand failing.
+var randRead = rand.Read
+
+// fallbackRandom generates the random bytes of IDs when crypto/rand fails.
+var fallbackRandom = struct {
+	sync.Mutex
+	r *mrand.Rand
+}{r: mrand.New(mrand.NewSource(time.Now().UnixNano()))}
+
+// readRandom fills b with random bytes. IDs only need to be unique, so when
+// crypto/rand fails it falls back to a pseudo-random source rather than failing
+// the log call.
+func readRandom(b []byte) {
+	if _, err := randRead(b); err == nil {
+		return
+	}
+	fallbackRandom.Lock()
+	defer fallbackRandom.Unlock()
+	_, _ = fallbackRandom.r.Read(b)
+}
+
+func formatUUID(b [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// entryIDKey is the key of the ID of every entry, as added by WithEntryUUID.
+const entryIDKey = "entry_id"
+
+// WithEntryUUID gives every entry a unique ID, under entry_id, so a single line
+// can be referred to unambiguously, for example in a support ticket. Unlike the
+// correlation ID, which is shared by the entries of a request, it differs on
+// every entry.
+func WithEntryUUID() Option {
+	return func(o *options) {
+		o.entryUUID = true
+	}
+}
+
+// entryIDs generates the IDs of entries. Reading random bytes for every entry is
+// too costly, so IDs are formatted as version 4 UUIDs made of 8 random bytes,
+// read once per process, followed by a counter.
+var entryIDs struct {
+	once    sync.Once
+	prefix  [8]byte
+	counter uint64
+}
+
+func entryID() zapcore.Field {
+	entryIDs.once.Do(func() { readRandom(entryIDs.prefix[:]) })
+	var b [16]byte
+	copy(b[:8], entryIDs.prefix[:])
+	binary.BigEndian.PutUint64(b[8:], atomic.AddUint64(&entryIDs.counter, 1))
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return zap.String(entryIDKey, formatUUID(b))
+}
@@ -0,0 +1,74 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestUUIDs(t *testing.T) {
+	tests := map[string]struct {
+		gen     func() string
+		pattern *regexp.Regexp
+	}{
+		"Should generate a version 4 UUID": {
+			gen:     UUIDv4,
+			pattern: regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`),
+		},
+		"Should generate a version 7 UUID": {
+			gen:     UUIDv7,
+			pattern: regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`),
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			first, second := tc.gen(), tc.gen()
+			require.Regexp(t, tc.pattern, first)
+			require.NotEqual(t, first, second)
+		})
+	}
+}
+
+func TestUUIDs_RandomUnavailable(t *testing.T) {
+	original := randRead
+	t.Cleanup(func() { randRead = original })
+	randRead = func([]byte) (int, error) { return 0, errors.New("entropy unavailable") }
+
+	for _, gen := range []func() string{UUIDv4, UUIDv7} {
+		var first, second string
+		require.NotPanics(t, func() { first, second = gen(), gen() })
+		require.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-[47][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, first)
+		require.NotEqual(t, first, second)
+	}
+}
+
+func TestUUIDv7_SortsByTime(t *testing.T) {
+	first := UUIDv7()
+	second := UUIDv7()
+	// Only the millisecond timestamp, the first 12 hex digits, is ordered.
+	require.LessOrEqual(t, first[:13], second[:13])
+}
+
+func TestWithEntryUUID(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithEntryUUID())
+
+	ctx, child := l.With(context.Background(), "request_id", "<request-id-value>")
+	child.Info(ctx, "first")
+	child.Info(ctx, "second")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 2)
+	pattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	for _, line := range lines {
+		require.Regexp(t, pattern, line["entry_id"])
+		require.Equal(t, "<request-id-value>", line["request_id"])
+	}
+	require.NotEqual(t, lines[0]["entry_id"], lines[1]["entry_id"])
+}
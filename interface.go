@@ -0,0 +1,34 @@
+package loggy
+
+import (
+	"context"
+)
+
+// Interface is the set of logging methods of Logger. Code that only logs can
+// depend on it rather than on Logger, so tests can substitute Nop or a fake,
+// such as loggytest.Fake. Deriving loggers, with With or Named, stays on Logger.
+type Interface interface {
+	Debug(ctx context.Context, args ...interface{})
+	Info(ctx context.Context, args ...interface{})
+	Warn(ctx context.Context, args ...interface{})
+	Error(ctx context.Context, args ...interface{})
+	DPanic(ctx context.Context, args ...interface{})
+	Panic(ctx context.Context, args ...interface{})
+	Fatal(ctx context.Context, args ...interface{})
+
+	Debugf(ctx context.Context, template string, args ...interface{})
+	Infof(ctx context.Context, template string, args ...interface{})
+	Warnf(ctx context.Context, template string, args ...interface{})
+	Errorf(ctx context.Context, template string, args ...interface{})
+	DPanicf(ctx context.Context, template string, args ...interface{})
+	Panicf(ctx context.Context, template string, args ...interface{})
+	Fatalf(ctx context.Context, template string, args ...interface{})
+
+	Debugw(ctx context.Context, msg string, args ...interface{})
+	Infow(ctx context.Context, msg string, args ...interface{})
+	Warnw(ctx context.Context, msg string, args ...interface{})
+	Errorw(ctx context.Context, msg string, args ...interface{})
+	DPanicw(ctx context.Context, msg string, args ...interface{})
+	Panicw(ctx context.Context, msg string, args ...interface{})
+	Fatalw(ctx context.Context, msg string, args ...interface{})
+}
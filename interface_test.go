@@ -0,0 +1,23 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	_ Interface = Logger{}
+	_ Interface = Nop()
+)
+
+func TestInterface(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	var l Interface = New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	l.Infow(context.Background(), "something goes here", "key", "value")
+	require.Equal(t, `{"level":"info","msg":"something goes here","key":"value"}`+"\n", buf.String())
+}
@@ -0,0 +1,70 @@
+package loggy
+
+import (
+	"net"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithIPMasking masks the addresses of fields constructed with IP to their first
+// bits, zeroing the rest, so a /24 keeps the network of an IPv4 client but not the
+// client itself. IPv4 addresses, including IPv4-mapped IPv6 ones, keep v4Bits
+// bits and IPv6 addresses v6Bits bits. Prefixes longer than an address are
+// clamped to its length.
+func WithIPMasking(v4Bits, v6Bits int) Option {
+	return func(o *options) {
+		o.ipMaskV4Bits = v4Bits
+		o.ipMaskV6Bits = v6Bits
+		o.ipMasking = true
+	}
+}
+
+// IP constructs a field that encodes ip in its text form under key, masked
+// according to WithIPMasking.
+func IP(key string, ip net.IP) zapcore.Field {
+	return zapcore.Field{Key: key, Type: zapcore.StringerType, Interface: ipValue{ip: ip}}
+}
+
+type ipValue struct {
+	ip net.IP
+	// masked is set when the address is masked to the prefix of v4Bits or v6Bits bits.
+	masked         bool
+	v4Bits, v6Bits int
+}
+
+func (v ipValue) String() string {
+	if !v.masked {
+		return v.ip.String()
+	}
+	ip, bits, size := v.ip.To4(), v.v4Bits, net.IPv4len*8
+	if ip == nil {
+		ip, bits, size = v.ip.To16(), v.v6Bits, net.IPv6len*8
+	}
+	if ip == nil {
+		return v.ip.String()
+	}
+	if bits > size {
+		bits = size
+	}
+	if bits < 0 {
+		bits = 0
+	}
+	return ip.Mask(net.CIDRMask(bits, size)).String()
+}
+
+// maskIPs applies the masking of the options to the fields constructed with IP.
+func (r *root) maskIPs(o *options, fields []zapcore.Field) []zapcore.Field {
+	if !o.ipMasking {
+		return fields
+	}
+	for i, f := range fields {
+		v, ok := f.Interface.(ipValue)
+		if !ok {
+			continue
+		}
+		v.masked, v.v4Bits, v.v6Bits = true, o.ipMaskV4Bits, o.ipMaskV6Bits
+		fields[i].Interface = v
+		r.stats.addRedacted(1)
+	}
+	return fields
+}
@@ -0,0 +1,78 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithIPMasking(t *testing.T) {
+	tests := map[string]struct {
+		ip               string
+		opts             []Option
+		expected         string
+		expectedRedacted uint64
+	}{
+		"Should log an IPv4 address as-is without masking": {
+			ip:       "203.0.113.42",
+			expected: `"client_ip":"203.0.113.42"`,
+		},
+		"Should mask an IPv4 address to /24": {
+			ip:               "203.0.113.42",
+			opts:             []Option{WithIPMasking(24, 48)},
+			expected:         `"client_ip":"203.0.113.0"`,
+			expectedRedacted: 1,
+		},
+		"Should mask an IPv6 address to /48": {
+			ip:               "2001:db8:85a3:8d3:1319:8a2e:370:7348",
+			opts:             []Option{WithIPMasking(24, 48)},
+			expected:         `"client_ip":"2001:db8:85a3::"`,
+			expectedRedacted: 1,
+		},
+		"Should mask an IPv4-mapped IPv6 address as IPv4": {
+			ip:               "::ffff:203.0.113.42",
+			opts:             []Option{WithIPMasking(24, 48)},
+			expected:         `"client_ip":"203.0.113.0"`,
+			expectedRedacted: 1,
+		},
+		"Should clamp a prefix longer than the address": {
+			ip:               "203.0.113.42",
+			opts:             []Option{WithIPMasking(48, 48)},
+			expected:         `"client_ip":"203.0.113.42"`,
+			expectedRedacted: 1,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), tc.opts...)
+
+			ctx, _ := l.With(context.Background(), IP("client_ip", net.ParseIP(tc.ip)))
+			l.Info(ctx, "something goes here")
+
+			require.Contains(t, buf.String(), tc.expected)
+			require.Equal(t, tc.expectedRedacted, l.Stats().Redacted)
+		})
+	}
+}
+
+func TestWithIPMasking_IPv4AndIPv6(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithIPMasking(24, 48))
+
+	l.Infow(context.Background(), "something goes here",
+		IP("client_ip", net.ParseIP("203.0.113.42")),
+		IP("proxy_ip", net.ParseIP("2001:db8:85a3:8d3:1319:8a2e:370:7348")),
+	)
+
+	require.Equal(t,
+		`{"level":"info","msg":"something goes here","client_ip":"203.0.113.0","proxy_ip":"2001:db8:85a3::"}`+"\n",
+		buf.String(),
+	)
+	require.Equal(t, uint64(2), l.Stats().Redacted)
+}
@@ -0,0 +1,124 @@
+package loggy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-systemd/v22/journal"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ErrJournaldUnavailable is returned by NewJournald when the process is not
+// running on a host with a reachable systemd journal.
+var ErrJournaldUnavailable = errors.New("loggy: systemd journal is not available")
+
+// NewJournald creates a Logger that writes entries at level and above to the
+// local systemd journal. Levels are mapped to journal priorities and structured
+// fields are sent as native journal fields, with keys uppercased to satisfy
+// journald's naming rules.
+func NewJournald(level zapcore.Level, opts ...Option) (Logger, error) {
+	if !journal.Enabled() {
+		return Logger{}, ErrJournaldUnavailable
+	}
+
+	// The core enables every level, like those of the other constructors, so
+	// SetLevel can enable levels below level.
+	core := &journaldCore{
+		LevelEnabler: zapcore.DebugLevel,
+		fields:       map[string]string{},
+	}
+	return newLogger(zap.New(core).Sugar(), newOptions(opts...), level), nil
+}
+
+// journalPriority maps a zap level to the matching syslog priority used by journald.
+// It is also used to encode levels as syslog severities.
+func journalPriority(lvl zapcore.Level) journal.Priority {
+	return journal.Priority(journaldLevels.toBackend(lvl))
+}
+
+// journalFieldName converts a field key into a valid journal field name.
+// Journal field names may only contain uppercase letters, digits and underscores,
+// and must not start with an underscore.
+func journalFieldName(key string) string {
+	name := []rune(strings.ToUpper(key))
+	for i, r := range name {
+		if !(('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') || r == '_') {
+			name[i] = '_'
+		}
+	}
+	return strings.TrimLeft(string(name), "_")
+}
+
+// journalFieldValue renders an encoded field value as a journal field value.
+func journalFieldValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// journaldCore is a zapcore.Core that sends entries to the systemd journal.
+type journaldCore struct {
+	zapcore.LevelEnabler
+	fields map[string]string
+}
+
+func (c *journaldCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &journaldCore{
+		LevelEnabler: c.LevelEnabler,
+		fields:       make(map[string]string, len(c.fields)+len(fields)),
+	}
+	for k, v := range c.fields {
+		clone.fields[k] = v
+	}
+	addJournalFields(clone.fields, fields)
+	return clone
+}
+
+func (c *journaldCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *journaldCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	vars := make(map[string]string, len(c.fields)+len(fields)+2)
+	for k, v := range c.fields {
+		vars[k] = v
+	}
+	addJournalFields(vars, fields)
+	if ent.LoggerName != "" {
+		vars["LOGGER"] = ent.LoggerName
+	}
+	if ent.Stack != "" {
+		vars["STACKTRACE"] = ent.Stack
+	}
+	return journal.Send(ent.Message, journalPriority(ent.Level), vars)
+}
+
+func (c *journaldCore) Sync() error {
+	return nil
+}
+
+func addJournalFields(vars map[string]string, fields []zapcore.Field) {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+	for k, v := range enc.Fields {
+		if name := journalFieldName(k); name != "" {
+			vars[name] = journalFieldValue(v)
+		}
+	}
+}
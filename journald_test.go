@@ -0,0 +1,86 @@
+package loggy
+
+import (
+	"testing"
+
+	"github.com/coreos/go-systemd/v22/journal"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestJournalPriority(t *testing.T) {
+	tests := map[string]struct {
+		level    zapcore.Level
+		priority journal.Priority
+	}{
+		"Should map debug level to debug priority": {
+			level:    zapcore.DebugLevel,
+			priority: 7,
+		},
+		"Should map info level to info priority": {
+			level:    zapcore.InfoLevel,
+			priority: 6,
+		},
+		"Should map warn level to warning priority": {
+			level:    zapcore.WarnLevel,
+			priority: 4,
+		},
+		"Should map error level to err priority": {
+			level:    zapcore.ErrorLevel,
+			priority: 3,
+		},
+		"Should map dpanic level to crit priority": {
+			level:    zapcore.DPanicLevel,
+			priority: 2,
+		},
+		"Should map panic level to crit priority": {
+			level:    zapcore.PanicLevel,
+			priority: 2,
+		},
+		"Should map fatal level to crit priority": {
+			level:    zapcore.FatalLevel,
+			priority: 2,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.priority, journalPriority(tc.level))
+		})
+	}
+}
+
+func TestJournalFieldName(t *testing.T) {
+	tests := map[string]struct {
+		key  string
+		want string
+	}{
+		"Should uppercase snake case keys": {
+			key:  "request_id",
+			want: "REQUEST_ID",
+		},
+		"Should replace invalid characters": {
+			key:  "http.status-code",
+			want: "HTTP_STATUS_CODE",
+		},
+		"Should strip leading underscores": {
+			key:  "_internal",
+			want: "INTERNAL",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, journalFieldName(tc.key))
+		})
+	}
+}
+
+func TestNewJournald_Unavailable(t *testing.T) {
+	if journal.Enabled() {
+		t.Skip("the systemd journal is available")
+	}
+
+	_, err := NewJournald(zapcore.InfoLevel)
+	require.ErrorIs(t, err, ErrJournaldUnavailable)
+}
@@ -0,0 +1,95 @@
+package loggy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithKeyValidation checks the key of every field with validate. A key it returns
+// an error for is converted to snake_case, so "User Name" is logged as user_name,
+// and a warning carrying the error is logged, at most once per second. A key is
+// kept as is when converting it leaves nothing, or the key of another field of
+// the entry. Fields without a key, such as zap.Skip, are not checked. A nil
+// validate defaults to SnakeCaseKey. This keeps the keys of ad-hoc fields from
+// drifting from the naming convention of the logs.
+func WithKeyValidation(validate func(key string) error) Option {
+	if validate == nil {
+		validate = SnakeCaseKey
+	}
+	return func(o *options) {
+		o.keyValidator = validate
+	}
+}
+
+// SnakeCaseKey returns an error if key is not in snake_case: lowercase letters
+// and digits separated by single underscores.
+func SnakeCaseKey(key string) error {
+	if key == "" || key != snakeCase(key) {
+		return fmt.Errorf("loggy: field key %q is not snake_case", key)
+	}
+	return nil
+}
+
+// snakeCase converts s to snake_case, splitting words on any character other
+// than a letter or digit, and before an uppercase letter following a lowercase one.
+func snakeCase(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	pendingSep := false
+	prevLower := false
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			pendingSep = b.Len() > 0
+			prevLower = false
+			continue
+		}
+		if unicode.IsUpper(r) && prevLower {
+			pendingSep = true
+		}
+		if pendingSep {
+			b.WriteByte('_')
+			pendingSep = false
+		}
+		prevLower = unicode.IsLower(r) || unicode.IsDigit(r)
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// validateKeys applies the key validator of the options to fields.
+func (c *core) validateKeys(o *options, ent zapcore.Entry, fields []zapcore.Field) []zapcore.Field {
+	if o.keyValidator == nil {
+		return fields
+	}
+	var keys map[string]struct{}
+	for i, f := range fields {
+		if f.Key == "" || f.Type == zapcore.InlineMarshalerType || f.Type == zapcore.SkipType {
+			// Inlined and skipped fields have no key of their own.
+			continue
+		}
+		err := o.keyValidator(f.Key)
+		if err == nil {
+			continue
+		}
+		if keys == nil {
+			keys = make(map[string]struct{}, len(fields))
+			for _, f := range fields {
+				keys[f.Key] = struct{}{}
+			}
+		}
+		sanitized := snakeCase(f.Key)
+		if _, taken := keys[sanitized]; taken || sanitized == "" {
+			sanitized = f.Key
+		}
+		keys[sanitized] = struct{}{}
+		if allowWarning(&c.root.lastKeyWarning, validationWarningInterval) {
+			c.warn(ent, "loggy: invalid field key", zap.String("key", f.Key), zap.String("sanitized_key", sanitized), zap.Error(err))
+		}
+		fields[i].Key = sanitized
+	}
+	return fields
+}
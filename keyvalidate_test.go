@@ -0,0 +1,108 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithKeyValidation(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithKeyValidation(nil))
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	l.Infow(ctx, "something goes here", "User Name", "gopher")
+
+	require.Equal(t,
+		`{"level":"warn","msg":"loggy: invalid field key","key":"User Name","sanitized_key":"user_name","error":"loggy: field key \"User Name\" is not snake_case"}`+"\n"+
+			`{"level":"info","msg":"something goes here","request_id":"<request-id-value>","user_name":"gopher"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestWithKeyValidation_KeptKeys(t *testing.T) {
+	tests := map[string]struct {
+		args     []interface{}
+		expected string
+	}{
+		"Should not check fields without a key": {
+			args:     []interface{}{zap.Skip(), zap.Inline(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error { enc.AddString("id", "<id-value>"); return nil }))},
+			expected: `{"level":"info","msg":"something goes here","id":"<id-value>"}` + "\n",
+		},
+		"Should keep a key made only of symbols": {
+			args: []interface{}{"!!!", "<value>"},
+			expected: `{"level":"warn","msg":"loggy: invalid field key","key":"!!!","sanitized_key":"!!!","error":"loggy: field key \"!!!\" is not snake_case"}` + "\n" +
+				`{"level":"info","msg":"something goes here","!!!":"<value>"}` + "\n",
+		},
+		"Should keep a key that would collide with another key": {
+			args: []interface{}{"id", "<id-value>", "_id", "<internal-id-value>"},
+			expected: `{"level":"warn","msg":"loggy: invalid field key","key":"_id","sanitized_key":"_id","error":"loggy: field key \"_id\" is not snake_case"}` + "\n" +
+				`{"level":"info","msg":"something goes here","id":"<id-value>","_id":"<internal-id-value>"}` + "\n",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithKeyValidation(nil))
+
+			l.Infow(context.Background(), "something goes here", tc.args...)
+
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestSnakeCaseKey(t *testing.T) {
+	tests := map[string]struct {
+		key       string
+		sanitized string
+		valid     bool
+	}{
+		"Should accept snake_case":         {key: "request_id", sanitized: "request_id", valid: true},
+		"Should accept digits":             {key: "http2_stream", sanitized: "http2_stream", valid: true},
+		"Should reject spaces":             {key: "User Name", sanitized: "user_name"},
+		"Should reject camelCase":          {key: "userID", sanitized: "user_id"},
+		"Should reject dashes and repeats": {key: "--trace--id", sanitized: "trace_id"},
+		"Should reject an empty key":       {key: "", sanitized: ""},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := SnakeCaseKey(tc.key)
+			if tc.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+			require.Equal(t, tc.sanitized, snakeCase(tc.key))
+		})
+	}
+}
+
+func TestWithKeyValidation_ThrottlesWarnings(t *testing.T) {
+	start := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+	t.Cleanup(func() { now = time.Now })
+	now = func() time.Time { return start }
+
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithKeyValidation(nil))
+
+	ctx, _ := l.With(context.Background())
+	l.Infow(ctx, "first", "User Name", "gopher", "Request ID", "<request-id-value>")
+	now = func() time.Time { return start.Add(time.Second) }
+	l.Infow(ctx, "second", "User Name", "gopher")
+
+	require.Equal(t,
+		`{"level":"warn","msg":"loggy: invalid field key","key":"User Name","sanitized_key":"user_name","error":"loggy: field key \"User Name\" is not snake_case"}`+"\n"+
+			`{"level":"info","msg":"first","user_name":"gopher","request_id":"<request-id-value>"}`+"\n"+
+			`{"level":"warn","msg":"loggy: invalid field key","key":"User Name","sanitized_key":"user_name","error":"loggy: field key \"User Name\" is not snake_case"}`+"\n"+
+			`{"level":"info","msg":"second","user_name":"gopher"}`+"\n",
+		buf.String(),
+	)
+}
@@ -0,0 +1,25 @@
+package loggy
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// resolveLazy replaces every value of type func() interface{} with the value it returns.
+// It runs after the level check, so an expensive value passed to the w-methods as
+//
+//	l.Debugw(ctx, "state", "dump", func() interface{} { return serialize(x) })
+//
+// is only computed for the entries that are logged. Entries held by Batch and
+// BufferedContext resolve their values when they are logged, not when written.
+func resolveLazy(fields []zapcore.Field) []zapcore.Field {
+	for i, f := range fields {
+		if f.Type != zapcore.ReflectType {
+			continue
+		}
+		if fn, ok := f.Interface.(func() interface{}); ok {
+			fields[i] = zap.Any(f.Key, fn())
+		}
+	}
+	return fields
+}
@@ -0,0 +1,50 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_LazyFieldValue(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithLevel(zapcore.InfoLevel))
+
+	calls := 0
+	dump := func() interface{} {
+		calls++
+		return map[string]int{"size": 3}
+	}
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	l.Debugw(ctx, "state", "dump", dump)
+	require.Equal(t, 0, calls)
+	require.Empty(t, buf.String())
+
+	l.Infow(ctx, "state", "dump", dump)
+	require.Equal(t, 1, calls)
+	require.Equal(t, `{"level":"info","msg":"state","request_id":"<request-id-value>","dump":{"size":3}}`+"\n", buf.String())
+}
+
+// BenchmarkLoggy_LazyFieldValueDisabled demonstrates that a lazy field value is not
+// computed when the entry's level is disabled.
+func BenchmarkLoggy_LazyFieldValueDisabled(b *testing.B) {
+	l := New(zap.NewNop().Sugar())
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+
+	dump := func() interface{} {
+		b.Fatal("lazy field value computed for a disabled level")
+		return nil
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		l.Debugw(ctx, "state", "dump", dump)
+	}
+}
@@ -0,0 +1,180 @@
+package loggy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithLevel enables entries at lvl and above. It can only narrow the levels
+// enabled by the underlying core, never widen them.
+func WithLevel(lvl zapcore.Level) Option {
+	return func(o *options) {
+		o.level = lvl
+	}
+}
+
+// WithLevelByName sets the minimum level of named loggers by name prefix,
+// overriding WithLevel for them. A prefix matches a logger named exactly like it
+// or any of its descendants, so "app.sql" matches "app.sql" and "app.sql.tx".
+// When several prefixes match, the longest one wins.
+func WithLevelByName(levels map[string]zapcore.Level) Option {
+	return func(o *options) {
+		o.levelsByName = make(map[string]zapcore.Level, len(levels))
+		o.minNamedLevel = zapcore.FatalLevel
+		for name, lvl := range levels {
+			o.levelsByName[name] = lvl
+			if lvl < o.minNamedLevel {
+				o.minNamedLevel = lvl
+			}
+		}
+	}
+}
+
+// levelFor returns the minimum level in force for the logger named name,
+// or nil if loggy does not restrict it.
+func (o *options) levelFor(name string) zapcore.LevelEnabler {
+	var (
+		match string
+		found bool
+		lvl   zapcore.Level
+	)
+	for prefix, l := range o.levelsByName {
+		if !hasNamePrefix(name, prefix) || (found && len(prefix) <= len(match)) {
+			continue
+		}
+		match, found, lvl = prefix, true, l
+	}
+	if found {
+		return lvl
+	}
+	return o.level
+}
+
+func hasNamePrefix(name, prefix string) bool {
+	return strings.HasPrefix(name, prefix) && (len(name) == len(prefix) || name[len(prefix)] == '.')
+}
+
+// SetLevel enables entries at lvl and above, like WithLevel, for l and every
+// logger sharing its root. Use Clone first to change the level of a copy only.
+// It returns an error, and leaves the level as is, when l was not created by New
+// or one of the constructors, or when the zap core given to New disables lvl.
+func (l Logger) SetLevel(lvl zapcore.Level) error {
+	if l.root == nil {
+		return errors.New("loggy: cannot set the level of a Logger that was not created by New")
+	}
+	if c, ok := l.s.Desugar().Core().(*core); ok && !c.base.Enabled(lvl) {
+		return fmt.Errorf("loggy: level %s is disabled by the underlying zap core", lvl)
+	}
+	l.root.optsMu.Lock()
+	defer l.root.optsMu.Unlock()
+	o := *l.root.options()
+	o.level = lvl
+	l.root.opts.Store(&o)
+	return nil
+}
+
+// LevelHandler returns an HTTP handler that reports the level of l on GET, and
+// sets the level of l and every logger sharing its root on PUT, like SetLevel.
+// It mirrors zap's AtomicLevel.ServeHTTP: both methods respond with a JSON body
+// such as {"level":"info"}, and PUT takes the same body. A PUT of a level
+// SetLevel rejects responds with a 400 status. The handler performs no
+// authentication, so it must only be served behind one, or on an internal port.
+func (l Logger) LevelHandler() http.Handler {
+	type payload struct {
+		Level *zapcore.Level `json:"level"`
+	}
+	type errorPayload struct {
+		Error string `json:"error"`
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var req payload
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = enc.Encode(errorPayload{Error: "loggy: invalid request body: " + err.Error()})
+				return
+			}
+			if req.Level == nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = enc.Encode(errorPayload{Error: "loggy: must specify a level"})
+				return
+			}
+			if err := l.SetLevel(*req.Level); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = enc.Encode(errorPayload{Error: err.Error()})
+				return
+			}
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			_ = enc.Encode(errorPayload{Error: "loggy: only GET and PUT are supported"})
+			return
+		}
+		lvl := l.EffectiveLevel(context.Background())
+		_ = enc.Encode(payload{Level: &lvl})
+	})
+}
+
+// OverrideLevel creates a child logger whose minimum level is lvl, and injects it
+// into ctx. The override takes precedence over WithLevel and WithLevelByName for
+// the child and every logger derived from it, so a single request can be logged
+// at DebugLevel. It cannot enable levels the underlying core has disabled.
+func (l Logger) OverrideLevel(ctx context.Context, lvl zapcore.Level) (context.Context, Logger) {
+	l = l.extractLogger(ctx)
+	newLogger := Logger{s: withCore(l.s, func(c *core) { c.level = lvl }), root: l.root}
+	return newLogger.inject(ctx), newLogger
+}
+
+// EffectiveLevel returns the lowest level enabled for the logger carried by ctx,
+// or by l if ctx does not carry one. It accounts for context overrides, levels
+// set by name and muted levels, so it can guard expensive work accurately.
+// If every level is disabled, it returns FatalLevel.
+func (l Logger) EffectiveLevel(ctx context.Context) zapcore.Level {
+	base := l.extractLogger(ctx).s.Desugar().Core()
+	enabled := base.Enabled
+	if c, ok := base.(*core); ok {
+		enabled = func(lvl zapcore.Level) bool { return c.enabled(lvl, c.name) }
+	}
+	for lvl := zapcore.DebugLevel; lvl < zapcore.FatalLevel; lvl++ {
+		if enabled(lvl) {
+			return lvl
+		}
+	}
+	return zapcore.FatalLevel
+}
+
+// WithMutedLevels drops every entry at one of levels, regardless of the minimum
+// level of the underlying core. Levels around a muted level are unaffected,
+// which makes it more surgical than raising the minimum level.
+func WithMutedLevels(levels ...zapcore.Level) Option {
+	return func(o *options) {
+		for _, lvl := range levels {
+			if lvl >= zapcore.DebugLevel && lvl <= zapcore.FatalLevel {
+				o.mutedLevels |= levelBit(lvl)
+			}
+		}
+	}
+}
+
+// levelSet is a bit set of zap levels.
+type levelSet uint8
+
+func levelBit(lvl zapcore.Level) levelSet {
+	return 1 << uint(lvl-zapcore.DebugLevel)
+}
+
+func (s levelSet) has(lvl zapcore.Level) bool {
+	if lvl < zapcore.DebugLevel || lvl > zapcore.FatalLevel {
+		return false
+	}
+	return s&levelBit(lvl) != 0
+}
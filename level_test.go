@@ -0,0 +1,227 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithMutedLevels(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithMutedLevels(zapcore.WarnLevel))
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	l.Info(ctx, "info")
+	l.Warn(ctx, "warn")
+	l.Warnw(ctx, "warnw")
+	l.Error(ctx, "error")
+
+	require.Equal(t,
+		`{"level":"info","msg":"info","request_id":"<request-id-value>"}`+"\n"+
+			`{"level":"error","msg":"error","request_id":"<request-id-value>"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestWithLevelByName(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(),
+		WithLevel(zapcore.InfoLevel),
+		WithLevelByName(map[string]zapcore.Level{"app.sql": zapcore.DebugLevel}),
+	)
+
+	ctx, app := l.Named(context.Background(), "app")
+	sqlCtx, sql := app.Named(ctx, "sql")
+	httpCtx, http := app.Named(ctx, "http")
+
+	sql.Debug(sqlCtx, "sql debug")
+	http.Debug(httpCtx, "http debug")
+	http.Info(httpCtx, "http info")
+	l.Debug(context.Background(), "root debug")
+
+	require.Equal(t,
+		`{"level":"debug","logger":"app.sql","msg":"sql debug"}`+"\n"+
+			`{"level":"info","logger":"app.http","msg":"http info"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestLogger_EffectiveLevel(t *testing.T) {
+	l := New(newZapTestLogger(t, zapcore.AddSync(bytes.NewBuffer([]byte{}))).Sugar(),
+		WithLevel(zapcore.InfoLevel),
+		WithLevelByName(map[string]zapcore.Level{"app.sql": zapcore.DebugLevel}),
+	)
+
+	plainCtx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	overriddenCtx, _ := l.OverrideLevel(plainCtx, zapcore.DebugLevel)
+	appCtx, _ := l.Named(plainCtx, "app")
+	sqlCtx, _ := l.Named(appCtx, "sql")
+	quietCtx, _ := l.OverrideLevel(sqlCtx, zapcore.ErrorLevel)
+
+	require.Equal(t, zapcore.InfoLevel, l.EffectiveLevel(context.Background()))
+	require.Equal(t, zapcore.InfoLevel, l.EffectiveLevel(plainCtx))
+	require.Equal(t, zapcore.DebugLevel, l.EffectiveLevel(overriddenCtx))
+	require.Equal(t, zapcore.InfoLevel, l.EffectiveLevel(appCtx))
+	require.Equal(t, zapcore.DebugLevel, l.EffectiveLevel(sqlCtx))
+	require.Equal(t, zapcore.ErrorLevel, l.EffectiveLevel(quietCtx))
+}
+
+func TestLogger_EffectiveLevel_NamedZapLogger(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Named("app").Sugar(),
+		WithLevel(zapcore.InfoLevel),
+		WithLevelByName(map[string]zapcore.Level{"app.sql": zapcore.DebugLevel}),
+	)
+
+	ctx, _ := l.Named(context.Background(), "sql")
+	require.Equal(t, zapcore.DebugLevel, l.EffectiveLevel(ctx))
+
+	l.Debug(ctx, "sql debug")
+	require.Equal(t, `{"level":"debug","logger":"app.sql","msg":"sql debug"}`+"\n", buf.String())
+}
+
+func TestLogger_EffectiveLevel_ForeignCore(t *testing.T) {
+	l := Logger{s: newZapTestLogger(t, zapcore.AddSync(bytes.NewBuffer([]byte{}))).WithOptions(zap.IncreaseLevel(zapcore.WarnLevel)).Sugar()}
+
+	require.Equal(t, zapcore.WarnLevel, l.EffectiveLevel(context.Background()))
+}
+
+func TestLogger_OverrideLevel(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithLevel(zapcore.InfoLevel))
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	debugCtx, _ := l.OverrideLevel(ctx, zapcore.DebugLevel)
+
+	l.Debug(ctx, "dropped")
+	l.Debug(debugCtx, "emitted")
+
+	require.Equal(t, `{"level":"debug","msg":"emitted","request_id":"<request-id-value>"}`+"\n", buf.String())
+}
+
+func TestLogger_SetLevel(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	ctx, child := l.With(context.Background(), "request_id", "<request-id-value>")
+	require.NoError(t, l.SetLevel(zapcore.WarnLevel))
+	child.Info(ctx, "dropped")
+	child.Warn(ctx, "emitted")
+
+	require.Equal(t, `{"level":"warn","msg":"emitted","request_id":"<request-id-value>"}`+"\n", buf.String())
+}
+
+func TestLogger_SetLevel_Constructor(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := NewProduction(zapcore.AddSync(buf), zapcore.InfoLevel, bufferedTestConfig())
+
+	ctx, child := l.With(context.Background(), "request_id", "<request-id-value>")
+	child.Debug(ctx, "dropped")
+	require.NoError(t, l.SetLevel(zapcore.DebugLevel))
+	require.Equal(t, zapcore.DebugLevel, l.EffectiveLevel(ctx))
+	child.Debug(ctx, "emitted")
+
+	// Reconfigure without WithLevel reverts to the level of the constructor.
+	require.NoError(t, l.Reconfigure())
+	child.Debug(ctx, "dropped")
+
+	require.Equal(t, `{"level":"debug","msg":"emitted","request_id":"<request-id-value>"}`+"\n", buf.String())
+}
+
+func TestLogger_SetLevel_Errors(t *testing.T) {
+	tests := map[string]struct {
+		logger   Logger
+		expected string
+	}{
+		"Should reject a zero Logger": {
+			logger:   Logger{},
+			expected: "loggy: cannot set the level of a Logger that was not created by New",
+		},
+		"Should reject a level disabled by the zap core": {
+			logger:   New(zap.NewExample(zap.IncreaseLevel(zapcore.InfoLevel)).Sugar()),
+			expected: "loggy: level debug is disabled by the underlying zap core",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			require.NotPanics(t, func() {
+				require.EqualError(t, tc.logger.SetLevel(zapcore.DebugLevel), tc.expected)
+			})
+		})
+	}
+}
+
+func TestLogger_LevelHandler(t *testing.T) {
+	tests := map[string]struct {
+		method       string
+		body         string
+		expectedCode int
+		expectedBody string
+		expectedLogs string
+	}{
+		"Should report the current level on GET": {
+			method:       http.MethodGet,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"level":"info"}`,
+			expectedLogs: `{"level":"info","msg":"info","request_id":"<request-id-value>"}` + "\n" +
+				`{"level":"warn","msg":"warn","request_id":"<request-id-value>"}` + "\n",
+		},
+		"Should set the level on PUT": {
+			method:       http.MethodPut,
+			body:         `{"level":"warn"}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"level":"warn"}`,
+			expectedLogs: `{"level":"warn","msg":"warn","request_id":"<request-id-value>"}` + "\n",
+		},
+		"Should enable DebugLevel on PUT": {
+			method:       http.MethodPut,
+			body:         `{"level":"debug"}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"level":"debug"}`,
+			expectedLogs: `{"level":"debug","msg":"debug","request_id":"<request-id-value>"}` + "\n" +
+				`{"level":"info","msg":"info","request_id":"<request-id-value>"}` + "\n" +
+				`{"level":"warn","msg":"warn","request_id":"<request-id-value>"}` + "\n",
+		},
+		"Should reject a PUT without a level": {
+			method:       http.MethodPut,
+			body:         `{}`,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"loggy: must specify a level"}`,
+			expectedLogs: `{"level":"info","msg":"info","request_id":"<request-id-value>"}` + "\n" +
+				`{"level":"warn","msg":"warn","request_id":"<request-id-value>"}` + "\n",
+		},
+		"Should reject other methods": {
+			method:       http.MethodPost,
+			expectedCode: http.StatusMethodNotAllowed,
+			expectedBody: `{"error":"loggy: only GET and PUT are supported"}`,
+			expectedLogs: `{"level":"info","msg":"info","request_id":"<request-id-value>"}` + "\n" +
+				`{"level":"warn","msg":"warn","request_id":"<request-id-value>"}` + "\n",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := NewProduction(zapcore.AddSync(buf), zapcore.InfoLevel, bufferedTestConfig())
+			ctx, child := l.With(context.Background(), "request_id", "<request-id-value>")
+
+			rec := httptest.NewRecorder()
+			l.LevelHandler().ServeHTTP(rec, httptest.NewRequest(tc.method, "/log/level", strings.NewReader(tc.body)))
+			child.Debug(ctx, "debug")
+			child.Info(ctx, "info")
+			child.Warn(ctx, "warn")
+
+			require.Equal(t, tc.expectedCode, rec.Code)
+			require.JSONEq(t, tc.expectedBody, rec.Body.String())
+			require.Equal(t, tc.expectedLogs, buf.String())
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package loggy
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// WithLevelFields attaches the key/value pairs in args only to the entries at
+// exactly level, such as a snapshot of internal state on Debug entries that would
+// clutter Info ones. args are pairs of a string key and a value, or zap Fields;
+// keys that are not strings are skipped along with their value. Calling it again
+// for the same level adds to the fields of that level.
+func WithLevelFields(level zapcore.Level, args ...interface{}) Option {
+	return func(o *options) {
+		if o.levelFields == nil {
+			o.levelFields = make(map[zapcore.Level][]zapcore.Field)
+		}
+		o.levelFields[level] = append(o.levelFields[level], keysAndValues(args)...)
+	}
+}
+
+// keysAndValues converts the loosely typed key/value pairs of the sugared
+// logger to fields.
+func keysAndValues(args []interface{}) []zapcore.Field {
+	fields := make([]zapcore.Field, 0, len(args)/2)
+	for i := 0; i < len(args); i++ {
+		if f, ok := args[i].(zapcore.Field); ok {
+			fields = append(fields, f)
+			continue
+		}
+		if i == len(args)-1 {
+			break
+		}
+		if key, ok := args[i].(string); ok {
+			fields = append(fields, Any(key, args[i+1]))
+		}
+		i++
+	}
+	return fields
+}
@@ -0,0 +1,58 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithLevelFields(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(),
+		WithLevelFields(zapcore.DebugLevel, "debug_snapshot", map[string]int{"queue_depth": 3}, zap.Bool("verbose", true)),
+	)
+
+	ctx, child := l.With(context.Background(), "request_id", "<request-id-value>")
+	child.Debug(ctx, "debug")
+	child.Info(ctx, "info")
+
+	require.Equal(t,
+		`{"level":"debug","msg":"debug","debug_snapshot":{"queue_depth":3},"verbose":true,"request_id":"<request-id-value>"}`+"\n"+
+			`{"level":"info","msg":"info","request_id":"<request-id-value>"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestKeysAndValues(t *testing.T) {
+	tests := map[string]struct {
+		args     []interface{}
+		expected []zapcore.Field
+	}{
+		"Should convert key/value pairs": {
+			args:     []interface{}{"a", "b", "c", 1},
+			expected: []zapcore.Field{zap.String("a", "b"), zap.Int("c", 1)},
+		},
+		"Should keep fields": {
+			args:     []interface{}{zap.Bool("a", true), "b", "c"},
+			expected: []zapcore.Field{zap.Bool("a", true), zap.String("b", "c")},
+		},
+		"Should skip a non-string key and its value": {
+			args:     []interface{}{1, "a", "b", "c"},
+			expected: []zapcore.Field{zap.String("b", "c")},
+		},
+		"Should drop a dangling key": {
+			args:     []interface{}{"a", "b", "c"},
+			expected: []zapcore.Field{zap.String("a", "b")},
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, keysAndValues(tc.args))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package loggy
+
+import (
+	"github.com/coreos/go-systemd/v22/journal"
+	"go.uber.org/zap/zapcore"
+)
+
+// levelMapper translates loggy levels, which are zap levels, to the levels of a
+// backend and back. Backend levels are represented as ints, which all of the
+// supported backends use underneath.
+type levelMapper struct {
+	// levels holds the backend level of every level from DebugLevel to FatalLevel.
+	levels [zapcore.FatalLevel - zapcore.DebugLevel + 1]int
+	// fallback is the backend level of levels outside of that range.
+	fallback int
+}
+
+// Level mappers of the supported backends.
+var (
+	zapLevels = levelMapper{
+		levels:   [...]int{-1, 0, 1, 2, 3, 4, 5},
+		fallback: int(zapcore.InfoLevel),
+	}
+	// slogLevels maps every level to the slog level with the same name. slog levels
+	// are four apart, so levels above ErrorLevel keep their order.
+	slogLevels = levelMapper{
+		levels:   [...]int{-4, 0, 4, 8, 12, 16, 20},
+		fallback: 0,
+	}
+	// journaldLevels maps every level to a syslog priority, as used by journald.
+	// Levels above ErrorLevel are critical: alert and emerg are meant for the whole
+	// system, and journald broadcasts emerg to every terminal. Critical priorities
+	// read back as DPanicLevel.
+	journaldLevels = levelMapper{
+		levels: [...]int{
+			int(journal.PriDebug),
+			int(journal.PriInfo),
+			int(journal.PriWarning),
+			int(journal.PriErr),
+			int(journal.PriCrit),
+			int(journal.PriCrit),
+			int(journal.PriCrit),
+		},
+		fallback: int(journal.PriInfo),
+	}
+)
+
+func (m levelMapper) toBackend(lvl zapcore.Level) int {
+	if lvl < zapcore.DebugLevel || lvl > zapcore.FatalLevel {
+		return m.fallback
+	}
+	return m.levels[lvl-zapcore.DebugLevel]
+}
+
+// fromBackend returns the level mapped to the backend level v, and reports whether there is one.
+func (m levelMapper) fromBackend(v int) (zapcore.Level, bool) {
+	for i, l := range m.levels {
+		if l == v {
+			return zapcore.DebugLevel + zapcore.Level(i), true
+		}
+	}
+	return zapcore.InfoLevel, false
+}
@@ -0,0 +1,52 @@
+package loggy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLevelMapper_RoundTrip(t *testing.T) {
+	tests := map[string]struct {
+		mapper levelMapper
+	}{
+		"Should round trip zap levels":  {mapper: zapLevels},
+		"Should round trip slog levels": {mapper: slogLevels},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			for lvl := zapcore.DebugLevel; lvl <= zapcore.FatalLevel; lvl++ {
+				got, ok := tc.mapper.fromBackend(tc.mapper.toBackend(lvl))
+				require.True(t, ok, lvl)
+				require.Equal(t, lvl, got)
+			}
+		})
+	}
+}
+
+func TestLevelMapper_JournaldLevels(t *testing.T) {
+	for lvl := zapcore.DebugLevel; lvl <= zapcore.FatalLevel; lvl++ {
+		got, ok := journaldLevels.fromBackend(journaldLevels.toBackend(lvl))
+		require.True(t, ok, lvl)
+		if lvl > zapcore.ErrorLevel {
+			require.Equal(t, zapcore.DPanicLevel, got)
+			continue
+		}
+		require.Equal(t, lvl, got)
+	}
+}
+
+func TestLevelMapper_ZapLevelsAreIdentity(t *testing.T) {
+	for lvl := zapcore.DebugLevel; lvl <= zapcore.FatalLevel; lvl++ {
+		require.Equal(t, int(lvl), zapLevels.toBackend(lvl))
+	}
+}
+
+func TestLevelMapper_UnknownLevels(t *testing.T) {
+	require.Equal(t, slogLevels.fallback, slogLevels.toBackend(zapcore.FatalLevel+1))
+
+	_, ok := slogLevels.fromBackend(1)
+	require.False(t, ok)
+}
@@ -0,0 +1,27 @@
+package loggy
+
+import (
+	"context"
+)
+
+// Sync flushes any buffered entries of l, such as those of NewBuffered.
+func (l Logger) Sync() error {
+	return l.s.Sync()
+}
+
+// Close flushes any buffered entries of l, waiting at most until ctx is done.
+// If ctx is done first, Close returns ctx.Err() and flushing carries on in the
+// background. Close gives every Logger the same shutdown call, whatever it writes to.
+func (l Logger) Close(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- l.s.Sync()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
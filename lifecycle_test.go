@@ -0,0 +1,43 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// blockingSyncer is a WriteSyncer whose Sync blocks until release is closed.
+type blockingSyncer struct {
+	bytes.Buffer
+	release chan struct{}
+}
+
+func (s *blockingSyncer) Sync() error {
+	<-s.release
+	return nil
+}
+
+func TestLogger_Close(t *testing.T) {
+	ws := &blockingSyncer{release: make(chan struct{})}
+	close(ws.release)
+	l := New(newZapTestLogger(t, ws).Sugar())
+
+	require.NoError(t, l.Close(context.Background()))
+}
+
+func TestLogger_Close_ReturnsOnDeadline(t *testing.T) {
+	ws := &blockingSyncer{release: make(chan struct{})}
+	t.Cleanup(func() { close(ws.release) })
+	l := New(newZapTestLogger(t, ws).Sugar())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := l.Close(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, int64(time.Since(start)), int64(time.Second))
+}
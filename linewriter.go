@@ -0,0 +1,125 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// JSONLineWriter returns an io.Writer that re-emits newline-delimited JSON logs,
+// such as the output of a subprocess, through the logger carried by ctx.
+// The level and message are taken from the level and msg (or message) keys of
+// each object and the remaining keys are logged as fields, so entries gain the
+// context's correlation fields. Lines that are not JSON objects are logged
+// verbatim at InfoLevel. Levels above ErrorLevel are logged at ErrorLevel so a
+// subprocess cannot make the caller panic or exit. A trailing line without a
+// newline is held until the line is completed or Sync is called, which logs it
+// and syncs the logger, so call Sync once the subprocess exits.
+func (l Logger) JSONLineWriter(ctx context.Context) zapcore.WriteSyncer {
+	// Skip the frames of logw and logLine so the caller is the writer of the line.
+	return &jsonLineWriter{s: l.sugar(ctx).Desugar().WithOptions(zap.AddCallerSkip(2)).Sugar()}
+}
+
+type jsonLineWriter struct {
+	s *zap.SugaredLogger
+
+	mu      sync.Mutex
+	pending []byte
+}
+
+func (w *jsonLineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(w.pending[:i])
+		w.pending = w.pending[i+1:]
+	}
+	// Release the consumed prefix so the buffer does not grow without bound.
+	w.pending = append([]byte(nil), w.pending...)
+	return len(p), nil
+}
+
+// Sync logs the trailing line held since the last newline, if any, and syncs the logger.
+func (w *jsonLineWriter) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.logLine(w.pending)
+	w.pending = nil
+	return w.s.Sync()
+}
+
+func (w *jsonLineWriter) logLine(line []byte) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return
+	}
+
+	var obj map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil || obj == nil {
+		// The line is not JSON, or is the null literal, which decodes to a nil map.
+		w.s.Info(string(line))
+		return
+	}
+
+	level := zapcore.InfoLevel
+	if v, ok := obj["level"].(string); ok {
+		if err := level.UnmarshalText([]byte(v)); err != nil {
+			level = zapcore.InfoLevel
+		}
+		delete(obj, "level")
+	}
+	if level > zapcore.ErrorLevel {
+		level = zapcore.ErrorLevel
+	}
+
+	var msg string
+	for _, key := range []string{"msg", "message"} {
+		if v, ok := obj[key].(string); ok {
+			msg = v
+			delete(obj, key)
+			break
+		}
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	keysAndValues := make([]interface{}, 0, 2*len(keys))
+	for _, k := range keys {
+		keysAndValues = append(keysAndValues, k, jsonValue(obj[k]))
+	}
+
+	logw(w.s, level, msg, keysAndValues...)
+}
+
+// jsonValue turns a decoded number back into a numeric value so it is not
+// re-encoded as a string.
+func jsonValue(v interface{}) interface{} {
+	n, ok := v.(json.Number)
+	if !ok {
+		return v
+	}
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+	return n.String()
+}
@@ -0,0 +1,72 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_JSONLineWriter(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	w := l.JSONLineWriter(ctx)
+
+	output := `{"level":"warn","msg":"disk almost full","free_bytes":1024,"mount":"/data"}
+starting worker
+null
+{"message":"no level here","attempt":2}
+{"level":"fatal","msg":"worker crashed"}
+{"level":"info","msg":"split across`
+	_, err := io.WriteString(w, output)
+	require.NoError(t, err)
+	_, err = io.WriteString(w, ` writes"}`+"\n")
+	require.NoError(t, err)
+
+	if *updateGolden {
+		t.Log("Updating golden file:", goldenFilename(t))
+		require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+		require.NoError(t, os.WriteFile(goldenFilename(t), buf.Bytes(), 0644))
+	}
+
+	golden, err := os.ReadFile(goldenFilename(t))
+	require.NoError(t, err)
+	require.Equal(t, buf.Bytes(), golden)
+}
+
+func TestLogger_JSONLineWriter_Sync(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	w := l.JSONLineWriter(context.Background())
+	_, err := io.WriteString(w, `{"level":"info","msg":"first"}`+"\n"+`{"level":"warn","msg":"no trailing newline"}`)
+	require.NoError(t, err)
+	require.Equal(t, `{"level":"info","msg":"first"}`+"\n", buf.String())
+
+	require.NoError(t, w.Sync())
+	require.NoError(t, w.Sync())
+	require.Equal(t,
+		`{"level":"info","msg":"first"}`+"\n"+
+			`{"level":"warn","msg":"no trailing newline"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestLogger_JSONLineWriter_Caller(t *testing.T) {
+	l, logs := newCallerTestLogger(t)
+
+	w := l.JSONLineWriter(context.Background())
+	_, err := w.Write([]byte(`{"level":"info","msg":"from subprocess"}` + "\n"))
+	_, file, line, _ := runtime.Caller(0)
+	require.NoError(t, err)
+
+	requireCaller(t, logs, file, line-1)
+}
@@ -0,0 +1,42 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_LogAt(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := NewProduction(zapcore.AddSync(buf), zapcore.InfoLevel, WithEncoderConfig(zapcore.EncoderConfig{
+		MessageKey:  "msg",
+		LevelKey:    "level",
+		TimeKey:     "ts",
+		NameKey:     "logger",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+		EncodeTime:  zapcore.ISO8601TimeEncoder,
+	}))
+
+	ctx, _ := l.Named(context.Background(), "replay")
+	ctx, _ = l.With(ctx, "request_id", "<request-id-value>")
+	at := time.Date(2019, 3, 14, 15, 9, 26, 0, time.UTC)
+	l.LogAt(ctx, at, zapcore.WarnLevel, "order placed", "order_id", 7)
+	l.LogAt(ctx, at, zapcore.DebugLevel, "dropped")
+	l.LogAt(ctx, at.Add(time.Second), zapcore.FatalLevel, "replayed without exiting")
+
+	if *updateGolden {
+		t.Log("Updating golden file:", goldenFilename(t))
+		require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+		require.NoError(t, os.WriteFile(goldenFilename(t), buf.Bytes(), 0644))
+	}
+
+	golden, err := os.ReadFile(goldenFilename(t))
+	require.NoError(t, err)
+	require.Equal(t, buf.Bytes(), golden)
+}
@@ -0,0 +1,344 @@
+package loggy
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewLogfmt creates a Logger that writes logfmt entries at level and above to ws,
+// such as level=info msg="something goes here" key=value, for tooling that prefers
+// it over JSON. Values containing spaces, equals signs or quotes are quoted, and
+// nested objects and arrays are flattened into dotted keys, such as http.status.
+// It uses zap's production encoder config, with ISO8601 times, unless
+// WithEncoderConfig is given.
+func NewLogfmt(ws zapcore.WriteSyncer, level zapcore.Level, opts ...Option) Logger {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	return build(newLogfmtEncoder, cfg, ws, level, newOptions(opts...))
+}
+
+var logfmtPool = buffer.NewPool()
+
+// logfmtEncoder is a zapcore.Encoder writing logfmt. Fields in a namespace or
+// nested in an object are written with their keys prefixed by prefix.
+type logfmtEncoder struct {
+	cfg    *zapcore.EncoderConfig
+	buf    *buffer.Buffer
+	prefix string
+}
+
+func newLogfmtEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	return &logfmtEncoder{cfg: &cfg, buf: logfmtPool.Get()}
+}
+
+func (enc *logfmtEncoder) Clone() zapcore.Encoder {
+	clone := &logfmtEncoder{cfg: enc.cfg, buf: logfmtPool.Get(), prefix: enc.prefix}
+	clone.buf.Write(enc.buf.Bytes())
+	return clone
+}
+
+func (enc *logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	final := &logfmtEncoder{cfg: enc.cfg, buf: logfmtPool.Get()}
+	cfg := enc.cfg
+
+	if cfg.LevelKey != "" && cfg.EncodeLevel != nil {
+		cfg.EncodeLevel(ent.Level, valueEncoder{enc: final, key: cfg.LevelKey})
+	}
+	if cfg.TimeKey != "" {
+		final.AddTime(cfg.TimeKey, ent.Time)
+	}
+	if ent.LoggerName != "" && cfg.NameKey != "" {
+		nameEncoder := cfg.EncodeName
+		if nameEncoder == nil {
+			nameEncoder = zapcore.FullNameEncoder
+		}
+		nameEncoder(ent.LoggerName, valueEncoder{enc: final, key: cfg.NameKey})
+	}
+	if ent.Caller.Defined && cfg.CallerKey != "" && cfg.EncodeCaller != nil {
+		cfg.EncodeCaller(ent.Caller, valueEncoder{enc: final, key: cfg.CallerKey})
+	}
+	if cfg.MessageKey != "" {
+		final.AddString(cfg.MessageKey, ent.Message)
+	}
+	if enc.buf.Len() > 0 {
+		if final.buf.Len() > 0 {
+			final.buf.AppendByte(' ')
+		}
+		final.buf.Write(enc.buf.Bytes())
+	}
+
+	final.prefix = enc.prefix
+	for _, f := range fields {
+		f.AddTo(final)
+	}
+	final.prefix = ""
+
+	if ent.Stack != "" && cfg.StacktraceKey != "" {
+		final.AddString(cfg.StacktraceKey, ent.Stack)
+	}
+	if cfg.LineEnding != "" {
+		final.buf.AppendString(cfg.LineEnding)
+	} else {
+		final.buf.AppendString(zapcore.DefaultLineEnding)
+	}
+	return final.buf, nil
+}
+
+// nested returns an encoder writing to the same buffer with keys prefixed by key.
+func (enc *logfmtEncoder) nested(key string) *logfmtEncoder {
+	return &logfmtEncoder{cfg: enc.cfg, buf: enc.buf, prefix: enc.prefix + key + "."}
+}
+
+func (enc *logfmtEncoder) addKey(key string) {
+	if enc.buf.Len() > 0 {
+		enc.buf.AppendByte(' ')
+	}
+	enc.buf.AppendString(strings.Map(sanitizeKeyRune, enc.prefix+key))
+	enc.buf.AppendByte('=')
+}
+
+// sanitizeKeyRune replaces the runes that cannot appear in a key with underscores.
+func sanitizeKeyRune(r rune) rune {
+	if needsQuoting(r) || r == utf8.RuneError {
+		return '_'
+	}
+	return r
+}
+
+// appendValue writes s, quoted and escaped if it is empty or contains anything
+// other than printable characters that are neither spaces, equals signs nor quotes.
+func (enc *logfmtEncoder) appendValue(s string) {
+	if s == "" || strings.IndexFunc(s, needsQuoting) >= 0 {
+		enc.buf.AppendString(strconv.Quote(s))
+		return
+	}
+	enc.buf.AppendString(s)
+}
+
+func needsQuoting(r rune) bool {
+	return r == ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r)
+}
+
+func (enc *logfmtEncoder) AddArray(key string, arr zapcore.ArrayMarshaler) error {
+	return arr.MarshalLogArray(&logfmtArrayEncoder{enc: enc.nested(key)})
+}
+
+func (enc *logfmtEncoder) AddObject(key string, obj zapcore.ObjectMarshaler) error {
+	return obj.MarshalLogObject(enc.nested(key))
+}
+
+func (enc *logfmtEncoder) AddBinary(key string, v []byte) {
+	enc.AddString(key, base64.StdEncoding.EncodeToString(v))
+}
+
+func (enc *logfmtEncoder) AddByteString(key string, v []byte) {
+	enc.AddString(key, string(v))
+}
+
+func (enc *logfmtEncoder) AddBool(key string, v bool) {
+	enc.addKey(key)
+	enc.buf.AppendBool(v)
+}
+
+func (enc *logfmtEncoder) AddComplex128(key string, v complex128) {
+	enc.AddString(key, strconv.FormatComplex(v, 'g', -1, 128))
+}
+
+func (enc *logfmtEncoder) AddComplex64(key string, v complex64) {
+	enc.AddString(key, strconv.FormatComplex(complex128(v), 'g', -1, 64))
+}
+
+func (enc *logfmtEncoder) AddDuration(key string, v time.Duration) {
+	if enc.cfg.EncodeDuration == nil {
+		enc.AddInt64(key, int64(v))
+		return
+	}
+	enc.cfg.EncodeDuration(v, valueEncoder{enc: enc, key: key})
+}
+
+func (enc *logfmtEncoder) AddFloat64(key string, v float64) {
+	enc.addKey(key)
+	enc.buf.AppendFloat(v, 64)
+}
+
+func (enc *logfmtEncoder) AddFloat32(key string, v float32) {
+	enc.addKey(key)
+	enc.buf.AppendFloat(float64(v), 32)
+}
+
+func (enc *logfmtEncoder) AddInt64(key string, v int64) {
+	enc.addKey(key)
+	enc.buf.AppendInt(v)
+}
+
+func (enc *logfmtEncoder) AddUint64(key string, v uint64) {
+	enc.addKey(key)
+	enc.buf.AppendUint(v)
+}
+
+// AddReflected writes v in its JSON form, with the objects and arrays it is
+// made of flattened into dotted keys, like those added with AddObject.
+func (enc *logfmtEncoder) AddReflected(key string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var decoded interface{}
+	if err := dec.Decode(&decoded); err != nil {
+		return err
+	}
+	enc.addDecoded(key, decoded)
+	return nil
+}
+
+// addDecoded writes v, a value decoded from JSON, under key.
+func (enc *logfmtEncoder) addDecoded(key string, v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 {
+			enc.addKey(key)
+			enc.buf.AppendString("{}")
+			return
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		nested := enc.nested(key)
+		for _, k := range keys {
+			nested.addDecoded(k, v[k])
+		}
+	case []interface{}:
+		if len(v) == 0 {
+			enc.addKey(key)
+			enc.buf.AppendString("[]")
+			return
+		}
+		nested := enc.nested(key)
+		for i, e := range v {
+			nested.addDecoded(strconv.Itoa(i), e)
+		}
+	case json.Number:
+		enc.addKey(key)
+		enc.buf.AppendString(v.String())
+	case string:
+		enc.AddString(key, v)
+	case bool:
+		enc.AddBool(key, v)
+	default:
+		enc.addKey(key)
+		enc.buf.AppendString("null")
+	}
+}
+
+func (enc *logfmtEncoder) OpenNamespace(key string) {
+	enc.prefix += key + "."
+}
+
+func (enc *logfmtEncoder) AddString(key, v string) {
+	enc.addKey(key)
+	enc.appendValue(v)
+}
+
+func (enc *logfmtEncoder) AddTime(key string, v time.Time) {
+	if enc.cfg.EncodeTime == nil {
+		enc.AddInt64(key, v.UnixNano())
+		return
+	}
+	enc.cfg.EncodeTime(v, valueEncoder{enc: enc, key: key})
+}
+
+func (enc *logfmtEncoder) AddInt(k string, v int)         { enc.AddInt64(k, int64(v)) }
+func (enc *logfmtEncoder) AddInt32(k string, v int32)     { enc.AddInt64(k, int64(v)) }
+func (enc *logfmtEncoder) AddInt16(k string, v int16)     { enc.AddInt64(k, int64(v)) }
+func (enc *logfmtEncoder) AddInt8(k string, v int8)       { enc.AddInt64(k, int64(v)) }
+func (enc *logfmtEncoder) AddUint(k string, v uint)       { enc.AddUint64(k, uint64(v)) }
+func (enc *logfmtEncoder) AddUint32(k string, v uint32)   { enc.AddUint64(k, uint64(v)) }
+func (enc *logfmtEncoder) AddUint16(k string, v uint16)   { enc.AddUint64(k, uint64(v)) }
+func (enc *logfmtEncoder) AddUint8(k string, v uint8)     { enc.AddUint64(k, uint64(v)) }
+func (enc *logfmtEncoder) AddUintptr(k string, v uintptr) { enc.AddUint64(k, uint64(v)) }
+
+// logfmtArrayEncoder flattens array elements into keys suffixed with their index.
+type logfmtArrayEncoder struct {
+	enc *logfmtEncoder
+	i   int
+}
+
+func (a *logfmtArrayEncoder) key() string {
+	k := strconv.Itoa(a.i)
+	a.i++
+	return k
+}
+
+func (a *logfmtArrayEncoder) AppendArray(v zapcore.ArrayMarshaler) error {
+	return a.enc.AddArray(a.key(), v)
+}
+
+func (a *logfmtArrayEncoder) AppendObject(v zapcore.ObjectMarshaler) error {
+	return a.enc.AddObject(a.key(), v)
+}
+
+func (a *logfmtArrayEncoder) AppendReflected(v interface{}) error {
+	return a.enc.AddReflected(a.key(), v)
+}
+
+func (a *logfmtArrayEncoder) AppendBool(v bool)              { a.enc.AddBool(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendByteString(v []byte)      { a.enc.AddByteString(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendComplex128(v complex128)  { a.enc.AddComplex128(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendComplex64(v complex64)    { a.enc.AddComplex64(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendFloat64(v float64)        { a.enc.AddFloat64(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendFloat32(v float32)        { a.enc.AddFloat32(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendInt(v int)                { a.enc.AddInt(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendInt64(v int64)            { a.enc.AddInt64(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendInt32(v int32)            { a.enc.AddInt32(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendInt16(v int16)            { a.enc.AddInt16(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendInt8(v int8)              { a.enc.AddInt8(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendString(v string)          { a.enc.AddString(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendUint(v uint)              { a.enc.AddUint(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendUint64(v uint64)          { a.enc.AddUint64(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendUint32(v uint32)          { a.enc.AddUint32(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendUint16(v uint16)          { a.enc.AddUint16(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendUint8(v uint8)            { a.enc.AddUint8(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendUintptr(v uintptr)        { a.enc.AddUintptr(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendDuration(v time.Duration) { a.enc.AddDuration(a.key(), v) }
+func (a *logfmtArrayEncoder) AppendTime(v time.Time)         { a.enc.AddTime(a.key(), v) }
+
+// valueEncoder writes the values appended by the encoders of the encoder config,
+// such as the level encoder, under key.
+type valueEncoder struct {
+	enc *logfmtEncoder
+	key string
+}
+
+func (v valueEncoder) AppendBool(b bool)             { v.enc.AddBool(v.key, b) }
+func (v valueEncoder) AppendByteString(b []byte)     { v.enc.AddByteString(v.key, b) }
+func (v valueEncoder) AppendComplex128(c complex128) { v.enc.AddComplex128(v.key, c) }
+func (v valueEncoder) AppendComplex64(c complex64)   { v.enc.AddComplex64(v.key, c) }
+func (v valueEncoder) AppendFloat64(f float64)       { v.enc.AddFloat64(v.key, f) }
+func (v valueEncoder) AppendFloat32(f float32)       { v.enc.AddFloat32(v.key, f) }
+func (v valueEncoder) AppendInt(i int)               { v.enc.AddInt(v.key, i) }
+func (v valueEncoder) AppendInt64(i int64)           { v.enc.AddInt64(v.key, i) }
+func (v valueEncoder) AppendInt32(i int32)           { v.enc.AddInt32(v.key, i) }
+func (v valueEncoder) AppendInt16(i int16)           { v.enc.AddInt16(v.key, i) }
+func (v valueEncoder) AppendInt8(i int8)             { v.enc.AddInt8(v.key, i) }
+func (v valueEncoder) AppendString(s string)         { v.enc.AddString(v.key, s) }
+func (v valueEncoder) AppendUint(u uint)             { v.enc.AddUint(v.key, u) }
+func (v valueEncoder) AppendUint64(u uint64)         { v.enc.AddUint64(v.key, u) }
+func (v valueEncoder) AppendUint32(u uint32)         { v.enc.AddUint32(v.key, u) }
+func (v valueEncoder) AppendUint16(u uint16)         { v.enc.AddUint16(v.key, u) }
+func (v valueEncoder) AppendUint8(u uint8)           { v.enc.AddUint8(v.key, u) }
+func (v valueEncoder) AppendUintptr(u uintptr)       { v.enc.AddUintptr(v.key, u) }
@@ -0,0 +1,61 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewLogfmt(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := NewLogfmt(zapcore.AddSync(buf), zapcore.DebugLevel, WithEncoderConfig(zapcore.EncoderConfig{
+		MessageKey:     "msg",
+		LevelKey:       "level",
+		NameKey:        "logger",
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+	}))
+
+	ctx, _ := l.Named(context.Background(), "app")
+	ctx, _ = l.With(ctx, "request_id", "<request-id-value>")
+	l.Infow(ctx, "something goes here",
+		"query", `name="gopher" and a=b`,
+		"empty", "",
+		"elapsed", 1500*time.Millisecond,
+		zap.Error(errors.New("boom")),
+		zap.Object("http", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddInt("status", 200)
+			return enc.AddArray("hops", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+				enc.AppendString("edge")
+				enc.AppendString("origin")
+				return nil
+			}))
+		})),
+	)
+	l.Infow(ctx, "m", "http", map[string]interface{}{"status": 200})
+	l.Infow(ctx, "reflected", "user", struct {
+		ID    int      `json:"id"`
+		Tags  []string `json:"tags"`
+		Extra map[string]string
+	}{ID: 7, Tags: []string{"a", "b c"}})
+	ctx, _ = l.Namespace(ctx, "db")
+	l.Warnw(ctx, "slow query", "rows", 3)
+
+	if *updateGolden {
+		t.Log("Updating golden file:", goldenFilename(t))
+		require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+		require.NoError(t, os.WriteFile(goldenFilename(t), buf.Bytes(), 0644))
+	}
+
+	golden, err := os.ReadFile(goldenFilename(t))
+	require.NoError(t, err)
+	require.Equal(t, buf.Bytes(), golden)
+}
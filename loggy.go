@@ -2,55 +2,229 @@ package loggy
 
 import (
 	"context"
+	"errors"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
 // Logger is an extension of a zap.s
 // It is configured with a list of fields
 // Configured fields are context keys (as string) to extract request-scoped values from context.Context
 type Logger struct {
-	s *zap.SugaredLogger
+	s    *zap.SugaredLogger
+	root *root
 }
 
-func New(zapLogger *zap.SugaredLogger) Logger {
+// New creates a Logger backed by zapLogger and configured with opts.
+// The core of zapLogger is wrapped so loggy can process fields before they are encoded.
+func New(zapLogger *zap.SugaredLogger, opts ...Option) Logger {
+	return newLogger(zapLogger, newOptions(opts...), nil)
+}
+
+// newLogger creates a Logger backed by zapLogger, configured with o. Options that
+// do not set a level, including those given to Reconfigure, enable level.
+func newLogger(zapLogger *zap.SugaredLogger, o *options, level zapcore.LevelEnabler) Logger {
+	r := newRoot(o, level)
+	r.contextKey = loggerctxkey
+	if o.contextKeyNamespace != "" {
+		r.contextKey = logContextKey("logger:" + o.contextKeyNamespace)
+	}
+	z := zapLogger.Desugar()
+	name := zapLoggerName(z)
+	s := z.WithOptions(zap.WrapCore(func(base zapcore.Core) zapcore.Core {
+		return &core{root: r, base: base, name: name}
+	})).Sugar()
 	return Logger{
-		s: zapLogger,
+		s:    s,
+		root: r,
+	}
+}
+
+// zapLoggerName returns the name given to l with Named, which zap does not expose.
+func zapLoggerName(l *zap.Logger) string {
+	probe := &nameProbe{Core: zapcore.NewNopCore()}
+	l.WithOptions(zap.WrapCore(func(zapcore.Core) zapcore.Core { return probe })).Check(zapcore.DebugLevel, "")
+	return probe.name
+}
+
+// nameProbe is a core that records the logger name of the entry it checks, and
+// writes nothing.
+type nameProbe struct {
+	zapcore.Core
+	name string
+}
+
+func (p *nameProbe) Enabled(zapcore.Level) bool {
+	return true
+}
+
+func (p *nameProbe) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	p.name = ent.LoggerName
+	return ce
+}
+
+// Reconfigure replaces the options of l with opts. The change is observed by l
+// and by every logger derived from it, including loggers already carried by a
+// context.Context, so it can be used to apply a configuration reload at runtime.
+// The zap logger passed to New keeps backing every logger. When opts do not
+// include WithLevel, the level reverts to the one given to the constructor, if
+// any, discarding SetLevel.
+//
+// Every entry is processed with a single set of options, so no entry is written
+// by a half-swapped pipeline. The entries held by BufferedContext for contexts
+// that are not done yet are drained with the options in effect before the
+// reload, then the new options take over, with no other write in between.
+// Errors writing the drained entries are returned, unless WithWriteErrorHandler
+// handles them.
+func (l *Logger) Reconfigure(opts ...Option) error {
+	if l.root == nil {
+		return errors.New("loggy: cannot reconfigure a Logger that was not created by New")
 	}
+	r := l.root
+	r.mu.Lock()
+	o := r.options()
+	err := r.flushBuffers()
+	r.setOptions(newOptions(opts...))
+	r.mu.Unlock()
+	return handleWriteError(o, err)
+}
+
+// Clone returns a copy of l with a root of its own: its options start as those of
+// l, but SetLevel and Reconfigure on the copy leave l untouched, and the copy
+// keeps its own Stats, rate limits and sampling. Batches of either are still not
+// interleaved with the entries of the other. This differs from With, whose
+// child shares the root of its parent. Clone is meant to isolate tests that
+// change logger state; both loggers still write to the same underlying zap core.
+func (l Logger) Clone() Logger {
+	if l.root == nil {
+		return l
+	}
+	r := newRoot(l.root.options(), l.root.defaultLevel)
+	r.contextKey = l.root.contextKey
+	r.nop = l.root.nop
+	r.mu = l.root.mu
+	return Logger{s: withCore(l.s, func(c *core) { c.root = r }), root: r}
+}
+
+// Core returns the zapcore.Core entries of l go through, for libraries that
+// accept one, so their entries get the same processing, such as redaction,
+// limits and sampling, and the fields of l. No context is available on this
+// path, so fields are not extracted from one, and loggers carried by contexts
+// are not consulted.
+func (l Logger) Core() zapcore.Core {
+	return l.s.Desugar().Core()
+}
+
+// SameRoot reports whether l and other were derived from the same call to New,
+// and therefore share options, stats and the underlying zap logger.
+// Zero-value loggers never share a root.
+func (l Logger) SameRoot(other Logger) bool {
+	return l.root != nil && l.root == other.root
 }
 
 // With creates a child logger, and optionally adds some context to that logger.
 // The child logger inherits the context of its parent.
 func (l Logger) With(ctx context.Context, args ...interface{}) (context.Context, Logger) {
 	l = l.extractLogger(ctx)
-	newLogger := New(l.s.With(args...))
-	return context.WithValue(ctx, loggerctxkey, newLogger), newLogger
+	l.root.traceFieldChange(l.s, args)
+	newLogger := Logger{s: l.s.With(args...), root: l.root}
+	return newLogger.inject(ctx), newLogger
+}
+
+// Named creates a child logger with name appended to its name, and injects it into ctx.
+// Names are joined with a period, so naming a child of "app" "sql" produces "app.sql".
+func (l Logger) Named(ctx context.Context, name string) (context.Context, Logger) {
+	l = l.extractLogger(ctx)
+	s := withCore(l.s.Named(name), func(c *core) {
+		if name == "" {
+			return
+		}
+		if c.name != "" {
+			c.name += "."
+		}
+		c.name += name
+	})
+	newLogger := Logger{s: s, root: l.root}
+	return newLogger.inject(ctx), newLogger
+}
+
+// WithComponent creates a child logger named name, which also carries name in the
+// component field, and injects it into ctx.
+func (l Logger) WithComponent(ctx context.Context, name string) (context.Context, Logger) {
+	ctx, _ = l.Named(ctx, name)
+	return l.With(ctx, componentKey, name)
+}
+
+// Namespace creates a child logger whose subsequent fields, whether added with
+// With or passed at the log site, are nested under key, and injects it into ctx.
+func (l Logger) Namespace(ctx context.Context, key string) (context.Context, Logger) {
+	return l.With(ctx, zap.Namespace(key))
+}
+
+// WithTrace creates a child logger carrying the trace_id and span_id fields.
+// It lets code that receives trace identifiers as plain strings, such as a queue
+// consumer reading message headers, correlate its logs the same way traced requests do.
+func (l Logger) WithTrace(ctx context.Context, traceID, spanID string) (context.Context, Logger) {
+	return l.With(ctx, traceIDKey, traceID, spanIDKey, spanID)
 }
 
 // Debug logs a message at DebugLevel. The message includes any fields passed
 // at the log site, as well as any fields extracted from the context.
 func (l Logger) Debug(ctx context.Context, args ...interface{}) {
-	l.extractLogger(ctx).s.Debug(args...)
+	s, ok := l.sugarAt(ctx, zapcore.DebugLevel)
+	if !ok {
+		return
+	}
+	if kv, ok := l.structuredArgs(ctx, args); ok {
+		s.Debugw("", kv...)
+		return
+	}
+	s.Debug(args...)
 }
 
 // Info logs a message at InfoLevel. The message includes any fields passed
 // at the log site, as well as any fields extracted from the context.
 func (l Logger) Info(ctx context.Context, args ...interface{}) {
-	l.extractLogger(ctx).s.Info(args...)
+	s, ok := l.sugarAt(ctx, zapcore.InfoLevel)
+	if !ok {
+		return
+	}
+	if kv, ok := l.structuredArgs(ctx, args); ok {
+		s.Infow("", kv...)
+		return
+	}
+	s.Info(args...)
 }
 
 // Warn uses fmt.Sprint to construct and log a message.
 // Warn logs a message at WarnLevel. The message includes any fields passed
 // at the log site, as well as any fields extracted from the context.
 func (l Logger) Warn(ctx context.Context, args ...interface{}) {
-	l.extractLogger(ctx).s.Warn(args...)
+	s, ok := l.sugarAt(ctx, zapcore.WarnLevel)
+	if !ok {
+		return
+	}
+	if kv, ok := l.structuredArgs(ctx, args); ok {
+		s.Warnw("", kv...)
+		return
+	}
+	s.Warn(args...)
 }
 
 // Error uses fmt.Sprint to construct and log a message.
 // Error logs a message at ErrorLevel. The message includes any fields passed
 // at the log site, as well as any fields extracted from the context.
 func (l Logger) Error(ctx context.Context, args ...interface{}) {
-	l.extractLogger(ctx).s.Error(args...)
+	s, ok := l.sugarAt(ctx, zapcore.ErrorLevel)
+	if !ok {
+		return
+	}
+	if kv, ok := l.structuredArgs(ctx, args); ok {
+		s.Errorw("", kv...)
+		return
+	}
+	s.Error(args...)
 }
 
 // DPanic logs a message at DPanicLevel. The message includes any fields passed
@@ -60,94 +234,182 @@ func (l Logger) Error(ctx context.Context, args ...interface{}) {
 // "development panic"). This is useful for catching errors that are
 // recoverable, but shouldn't ever happen.
 func (l Logger) DPanic(ctx context.Context, args ...interface{}) {
-	l.extractLogger(ctx).s.DPanic(args...)
+	s, ok := l.sugarAt(ctx, zapcore.DPanicLevel)
+	if !ok {
+		return
+	}
+	if kv, ok := l.structuredArgs(ctx, args); ok {
+		s.DPanicw("", kv...)
+		return
+	}
+	s.DPanic(args...)
 }
 
 // Panic logs a message at PanicLevel. The message includes any fields passed
 // at the log site, as well as any fields extracted from the context.
 //
-// The logger then panics, even if logging at PanicLevel is disabled.
+// The logger then panics, even if logging at PanicLevel is disabled, unless
+// it is configured with WithNoPanicFatal.
 func (l Logger) Panic(ctx context.Context, args ...interface{}) {
-	l.extractLogger(ctx).s.Panic(args...)
+	s := l.sugar(ctx)
+	if d, ok := downgrade(s, zapcore.PanicLevel); ok {
+		if kv, ok := l.structuredArgs(ctx, args); ok {
+			d.Errorw("", kv...)
+			return
+		}
+		d.Error(args...)
+		return
+	}
+	if kv, ok := l.structuredArgs(ctx, args); ok {
+		s.Panicw("", kv...)
+		return
+	}
+	s.Panic(args...)
 }
 
 // Fatal logs a message at FatalLevel. The message includes any fields passed
 // at the log site, as well as any fields extracted from the context.
 //
 // The logger then calls os.Exit(1), even if logging at FatalLevel is
-// disabled.
+// disabled, unless it is configured with WithNoPanicFatal.
 func (l Logger) Fatal(ctx context.Context, args ...interface{}) {
-	l.extractLogger(ctx).s.Fatal(args...)
+	s := l.sugar(ctx)
+	if d, ok := downgrade(s, zapcore.FatalLevel); ok {
+		if kv, ok := l.structuredArgs(ctx, args); ok {
+			d.Errorw("", kv...)
+			return
+		}
+		d.Error(args...)
+		return
+	}
+	if kv, ok := l.structuredArgs(ctx, args); ok {
+		s.Fatalw("", kv...)
+		return
+	}
+	s.Fatal(args...)
 }
 
 // Debugf uses fmt.Sprintf to log a templated message.
 func (l Logger) Debugf(ctx context.Context, template string, args ...interface{}) {
-	l.extractLogger(ctx).s.Debugf(template, args...)
+	if s, ok := l.sugarAt(ctx, zapcore.DebugLevel); ok {
+		s.Debugf(template, args...)
+	}
 }
 
 // Infof uses fmt.Sprintf to log a templated message.
 func (l Logger) Infof(ctx context.Context, template string, args ...interface{}) {
-	l.extractLogger(ctx).s.Infof(template, args...)
+	if s, ok := l.sugarAt(ctx, zapcore.InfoLevel); ok {
+		s.Infof(template, args...)
+	}
 }
 
 // Warnf uses fmt.Sprintf to log a templated message.
 func (l Logger) Warnf(ctx context.Context, template string, args ...interface{}) {
-	l.extractLogger(ctx).s.Warnf(template, args...)
+	if s, ok := l.sugarAt(ctx, zapcore.WarnLevel); ok {
+		s.Warnf(template, args...)
+	}
 }
 
 // Errorf uses fmt.Sprintf to log a templated message.
 func (l Logger) Errorf(ctx context.Context, template string, args ...interface{}) {
-	l.extractLogger(ctx).s.Errorf(template, args...)
+	if s, ok := l.sugarAt(ctx, zapcore.ErrorLevel); ok {
+		s.Errorf(template, args...)
+	}
 }
 
 // DPanicf uses fmt.Sprintf to log a templated message. In development, the logger then panics. (See zapcore.DPanicLevel for details.)
 func (l Logger) DPanicf(ctx context.Context, template string, args ...interface{}) {
-	l.extractLogger(ctx).s.DPanicf(template, args...)
+	if s, ok := l.sugarAt(ctx, zapcore.DPanicLevel); ok {
+		s.DPanicf(template, args...)
+	}
 }
 
 // Panicf uses fmt.Sprintf to log a templated message, then panics.
 func (l Logger) Panicf(ctx context.Context, template string, args ...interface{}) {
-	l.extractLogger(ctx).s.Panicf(template, args...)
+	s := l.sugar(ctx)
+	if d, ok := downgrade(s, zapcore.PanicLevel); ok {
+		d.Errorf(template, args...)
+		return
+	}
+	s.Panicf(template, args...)
 }
 
 // Fatalf uses fmt.Sprintf to log a templated message, then calls os.Exit.
 func (l Logger) Fatalf(ctx context.Context, template string, args ...interface{}) {
-	l.extractLogger(ctx).s.Fatalf(template, args...)
+	s := l.sugar(ctx)
+	if d, ok := downgrade(s, zapcore.FatalLevel); ok {
+		d.Errorf(template, args...)
+		return
+	}
+	s.Fatalf(template, args...)
 }
 
 // Debugw logs a message with some additional context.
+// A value of type func() interface{} is only called if the entry is written,
+// which keeps expensive debug values off the hot path.
 func (l Logger) Debugw(ctx context.Context, msg string, args ...interface{}) {
-	l.extractLogger(ctx).s.Debugw(msg, args...)
+	if s, ok := l.sugarAt(ctx, zapcore.DebugLevel); ok {
+		s.Debugw(msg, args...)
+	}
 }
 
 // Infow logs a message with some additional context.
 func (l Logger) Infow(ctx context.Context, msg string, args ...interface{}) {
-	l.extractLogger(ctx).s.Infow(msg, args...)
+	if s, ok := l.sugarAt(ctx, zapcore.InfoLevel); ok {
+		s.Infow(msg, args...)
+	}
 }
 
 // Warnw logs a message with some additional context.
 func (l Logger) Warnw(ctx context.Context, msg string, args ...interface{}) {
-	l.extractLogger(ctx).s.Warnw(msg, args...)
+	if s, ok := l.sugarAt(ctx, zapcore.WarnLevel); ok {
+		s.Warnw(msg, args...)
+	}
 }
 
 // Errorw logs a message with some additional context.
 func (l Logger) Errorw(ctx context.Context, msg string, args ...interface{}) {
-	l.extractLogger(ctx).s.Errorw(msg, args...)
+	if s, ok := l.sugarAt(ctx, zapcore.ErrorLevel); ok {
+		s.Errorw(msg, args...)
+	}
 }
 
 // DPanicw logs a message with some additional context. In development, the logger then panics. (See zapcore.DPanicLevel for details.)
 func (l Logger) DPanicw(ctx context.Context, msg string, args ...interface{}) {
-	l.extractLogger(ctx).s.DPanicw(msg, args...)
+	if s, ok := l.sugarAt(ctx, zapcore.DPanicLevel); ok {
+		s.DPanicw(msg, args...)
+	}
 }
 
 // Panicw logs a message with some additional context, then panics.
 func (l Logger) Panicw(ctx context.Context, msg string, args ...interface{}) {
-	l.extractLogger(ctx).s.Panicw(msg, args...)
+	s := l.sugar(ctx)
+	if d, ok := downgrade(s, zapcore.PanicLevel); ok {
+		d.Errorw(msg, args...)
+		return
+	}
+	s.Panicw(msg, args...)
 }
 
 // Fatalw logs a message with some additional context, then calls os.Exit.
 func (l Logger) Fatalw(ctx context.Context, msg string, args ...interface{}) {
-	l.extractLogger(ctx).s.Fatalw(msg, args...)
+	s := l.sugar(ctx)
+	if d, ok := downgrade(s, zapcore.FatalLevel); ok {
+		d.Errorw(msg, args...)
+		return
+	}
+	s.Fatalw(msg, args...)
+}
+
+// Printf returns a function that logs templated messages at level through the
+// logger carried by ctx. The returned function matches the Printf signature
+// expected by many third-party libraries for their logging sink.
+func (l Logger) Printf(ctx context.Context, level zapcore.Level) func(string, ...interface{}) {
+	// Skip the frame of the returned function so the caller is the log site.
+	s := l.sugar(ctx).Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar()
+	return func(template string, args ...interface{}) {
+		logf(s, level, template, args...)
+	}
 }
 
 type logContextKey string
@@ -156,10 +418,139 @@ const (
 	loggerctxkey = logContextKey("logger")
 )
 
+// componentKey is the field key of the component added by WithComponent.
+const componentKey = "component"
+
+// Field keys used to correlate entries with a distributed trace.
+const (
+	traceIDKey = "trace_id"
+	spanIDKey  = "span_id"
+)
+
+// sugar returns the sugared logger that writes entries for ctx, carrying the
+// fields computed from ctx in addition to those of the logger found in it.
+func (l Logger) sugar(ctx context.Context) *zap.SugaredLogger {
+	logger, ok := loggerFromContext(ctx, l.contextKey())
+	return l.sugarFrom(ctx, logger, ok)
+}
+
+// sugarAt returns the sugared logger that writes entries at lvl for ctx, like
+// sugar, or false when the logger carried by ctx disables lvl. Checking the level
+// first spares disabled entries the cost of computing fields from ctx. Entries at
+// DPanicLevel and above are always logged, as zap may panic or exit after them.
+func (l Logger) sugarAt(ctx context.Context, lvl zapcore.Level) (*zap.SugaredLogger, bool) {
+	logger, ok := loggerFromContext(ctx, l.contextKey())
+	s := l.s
+	if ok {
+		s = logger.s
+	}
+	if lvl < zapcore.DPanicLevel && !s.Desugar().Core().Enabled(lvl) {
+		return nil, false
+	}
+	return l.sugarFrom(ctx, logger, ok), true
+}
+
+// sugarFrom returns the sugared logger that writes entries for ctx, given the
+// logger found in ctx, if found.
+func (l Logger) sugarFrom(ctx context.Context, logger Logger, found bool) *zap.SugaredLogger {
+	if !found {
+		l.root.warnMissingLogger(l.s)
+		return l.root.extract(ctx, l.root.tagOrphan(l.s))
+	}
+	return logger.root.extract(ctx, logger.s)
+}
+
 func (l Logger) extractLogger(ctx context.Context) Logger {
-	logger, ok := ctx.Value(loggerctxkey).(Logger)
+	logger, ok := loggerFromContext(ctx, l.contextKey())
 	if !ok {
 		return l
 	}
 	return logger
 }
+
+// inject returns a copy of ctx carrying l.
+func (l Logger) inject(ctx context.Context) context.Context {
+	if l.root != nil {
+		ctx = l.root.markStart(ctx)
+	}
+	return &loggerContext{Context: ctx, key: l.contextKey(), logger: l}
+}
+
+// loggerContext is the context a logger is injected into. Finding the logger in
+// the very context it was injected into, as handlers behind Middleware do on
+// every call, then skips the walk up the context chain and the type assertion
+// of the value. With five values above it, BenchmarkLoggy_ExtractLogger went
+// from 60ns and an allocation to 11ns without allocating, and BenchmarkLoggy
+// from 19 allocations per request to 7.
+type loggerContext struct {
+	context.Context
+	key    logContextKey
+	logger Logger
+}
+
+func (c *loggerContext) Value(key interface{}) interface{} {
+	if k, ok := key.(logContextKey); ok && k == c.key {
+		return c.logger
+	}
+	return c.Context.Value(key)
+}
+
+// contextKey returns the key l is stored under in a context.
+func (l Logger) contextKey() logContextKey {
+	if l.root == nil {
+		return loggerctxkey
+	}
+	return l.root.contextKey
+}
+
+func loggerFromContext(ctx context.Context, key logContextKey) (Logger, bool) {
+	if c, ok := ctx.(*loggerContext); ok && c.key == key {
+		return c.logger, true
+	}
+	logger, ok := ctx.Value(key).(Logger)
+	return logger, ok
+}
+
+func logf(s *zap.SugaredLogger, level zapcore.Level, template string, args ...interface{}) {
+	if d, ok := downgrade(s, level); ok {
+		s, level = d, zapcore.ErrorLevel
+	}
+	switch level {
+	case zapcore.DebugLevel:
+		s.Debugf(template, args...)
+	case zapcore.InfoLevel:
+		s.Infof(template, args...)
+	case zapcore.WarnLevel:
+		s.Warnf(template, args...)
+	case zapcore.ErrorLevel:
+		s.Errorf(template, args...)
+	case zapcore.DPanicLevel:
+		s.DPanicf(template, args...)
+	case zapcore.PanicLevel:
+		s.Panicf(template, args...)
+	case zapcore.FatalLevel:
+		s.Fatalf(template, args...)
+	}
+}
+
+func logw(s *zap.SugaredLogger, level zapcore.Level, msg string, keysAndValues ...interface{}) {
+	if d, ok := downgrade(s, level); ok {
+		s, level = d, zapcore.ErrorLevel
+	}
+	switch level {
+	case zapcore.DebugLevel:
+		s.Debugw(msg, keysAndValues...)
+	case zapcore.InfoLevel:
+		s.Infow(msg, keysAndValues...)
+	case zapcore.WarnLevel:
+		s.Warnw(msg, keysAndValues...)
+	case zapcore.ErrorLevel:
+		s.Errorw(msg, keysAndValues...)
+	case zapcore.DPanicLevel:
+		s.DPanicw(msg, keysAndValues...)
+	case zapcore.PanicLevel:
+		s.Panicw(msg, keysAndValues...)
+	case zapcore.FatalLevel:
+		s.Fatalw(msg, keysAndValues...)
+	}
+}
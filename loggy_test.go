@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestLogger_LogUntemplatedMessage(t *testing.T) {
@@ -169,6 +170,117 @@ func TestLogger_LogMessageWithFields(t *testing.T) {
 	}
 }
 
+func TestLogger_Printf(t *testing.T) {
+	tests := map[string]struct {
+		level zapcore.Level
+	}{
+		"Should log with debug level": {
+			level: zapcore.DebugLevel,
+		},
+		"Should log with info level": {
+			level: zapcore.InfoLevel,
+		},
+		"Should log with warn level": {
+			level: zapcore.WarnLevel,
+		},
+		"Should log with error level": {
+			level: zapcore.ErrorLevel,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+
+			zapLogger := newZapTestLogger(t, zapcore.AddSync(buf))
+
+			l := New(zapLogger.Sugar())
+
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+
+			printf := l.Printf(ctx, tc.level)
+			printf("something goes here %s", "here")
+
+			if *updateGolden {
+				t.Log("Updating golden file:", goldenFilename(t))
+				require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+				require.NoError(t, os.WriteFile(goldenFilename(t), buf.Bytes(), 0644))
+			}
+
+			golden, err := os.ReadFile(goldenFilename(t))
+			require.NoError(t, err)
+			require.Equal(t, buf.Bytes(), golden)
+		})
+	}
+}
+
+func TestLogger_WithTrace(t *testing.T) {
+	httpBuf := bytes.NewBuffer([]byte{})
+	httpLogger := New(newZapTestLogger(t, zapcore.AddSync(httpBuf)).Sugar())
+	// An HTTP request is enriched from its trace headers by middleware.
+	httpCtx, _ := httpLogger.With(context.Background(), "trace_id", "<trace-id-value>", "span_id", "<span-id-value>")
+	httpLogger.Infow(httpCtx, "something goes here", "key", "value")
+
+	queueBuf := bytes.NewBuffer([]byte{})
+	queueLogger := New(newZapTestLogger(t, zapcore.AddSync(queueBuf)).Sugar())
+	// A queue consumer only has the identifiers as plain strings from the message headers.
+	queueCtx, _ := queueLogger.WithTrace(context.Background(), "<trace-id-value>", "<span-id-value>")
+	queueLogger.Infow(queueCtx, "something goes here", "key", "value")
+
+	require.Equal(t, httpBuf.String(), queueBuf.String())
+}
+
+func TestLogger_Reconfigure(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithLevel(zapcore.InfoLevel))
+
+	ctx, child := l.With(context.Background(), "request_id", "<request-id-value>")
+	child.Debug(ctx, "dropped")
+	require.Empty(t, buf.String())
+
+	require.NoError(t, l.Reconfigure(WithLevel(zapcore.DebugLevel)))
+
+	child.Debug(ctx, "emitted")
+	require.Equal(t, `{"level":"debug","msg":"emitted","request_id":"<request-id-value>"}`+"\n", buf.String())
+}
+
+func TestLogger_Reconfigure_NotCreatedByNew(t *testing.T) {
+	var l Logger
+	require.Error(t, l.Reconfigure(WithLevel(zapcore.DebugLevel)))
+}
+
+func TestLogger_WithComponent(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	ctx, _ = l.WithComponent(ctx, "cache")
+	l.Infow(ctx, "something goes here", "key", "value")
+
+	if *updateGolden {
+		t.Log("Updating golden file:", goldenFilename(t))
+		require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+		require.NoError(t, os.WriteFile(goldenFilename(t), buf.Bytes(), 0644))
+	}
+
+	golden, err := os.ReadFile(goldenFilename(t))
+	require.NoError(t, err)
+	require.Equal(t, buf.Bytes(), golden)
+}
+
+func TestLogger_SameRoot(t *testing.T) {
+	zapLogger := newZapTestLogger(t, zapcore.AddSync(bytes.NewBuffer([]byte{}))).Sugar()
+	parent := New(zapLogger)
+	ctx, child := parent.With(context.Background(), "request_id", "<request-id-value>")
+	_, grandchild := parent.Named(ctx, "app")
+
+	require.True(t, parent.SameRoot(child))
+	require.True(t, child.SameRoot(grandchild))
+	require.False(t, parent.SameRoot(New(zapLogger)))
+	require.False(t, Logger{}.SameRoot(Logger{}))
+}
+
 func goldenFilename(t *testing.T) string {
 	t.Helper()
 	return "testdata/" + t.Name() + ".golden"
@@ -188,6 +300,23 @@ func newZapTestLogger(t *testing.T, output zapcore.WriteSyncer, options ...zap.O
 	return zap.New(core).WithOptions(options...)
 }
 
+// newCallerTestLogger creates a Logger recording the caller of its entries, like
+// the constructors of this package, and returns it with the entries it logs.
+func newCallerTestLogger(t *testing.T) (Logger, *observer.ObservedLogs) {
+	t.Helper()
+	core, logs := observer.New(zapcore.DebugLevel)
+	return New(zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1)).Sugar()), logs
+}
+
+// requireCaller requires the only entry of logs to have been logged from file at line.
+func requireCaller(t *testing.T, logs *observer.ObservedLogs, file string, line int) {
+	t.Helper()
+	entries := logs.All()
+	require.Len(t, entries, 1)
+	require.Equal(t, file, entries[0].Caller.File)
+	require.Equal(t, line, entries[0].Caller.Line)
+}
+
 // BenchmarkLoggy benchmarks the recommended usage of the Logger.
 // It is intended to be run with the -benchmem flag.
 // The recommended usage of the Logger is to use the WithFields and Infow, Debugw, etc. methods.
@@ -323,3 +452,90 @@ func BenchmarkZap_NoLoggingContext(b *testing.B) {
 		extractLoggerFromContext(ctx).Infow("something goes here", "key", "value")
 	}
 }
+
+func TestLogger_Clone(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithLevel(zapcore.InfoLevel), WithRedactedKeys("password"))
+
+	clone := l.Clone()
+	require.False(t, l.SameRoot(clone))
+
+	require.NoError(t, clone.SetLevel(zapcore.ErrorLevel))
+	require.Equal(t, zapcore.InfoLevel, l.EffectiveLevel(context.Background()))
+	require.Equal(t, zapcore.ErrorLevel, clone.EffectiveLevel(context.Background()))
+
+	ctx, _ := clone.With(context.Background(), "request_id", "<request-id-value>")
+	clone.Info(ctx, "dropped")
+	clone.Errorw(ctx, "clone", "password", "<password-value>")
+	l.Info(context.Background(), "original")
+
+	require.Equal(t,
+		`{"level":"error","msg":"clone","request_id":"<request-id-value>","password":"[REDACTED]"}`+"\n"+
+			`{"level":"info","msg":"original"}`+"\n",
+		buf.String(),
+	)
+	require.Equal(t, Stats{Redacted: 1}, clone.Stats())
+	require.Equal(t, Stats{}, l.Stats())
+}
+
+// BenchmarkLoggy_ExtractLogger measures finding the logger carried by the context
+// it was injected into, as handlers behind Middleware do on every call.
+func BenchmarkLoggy_ExtractLogger(b *testing.B) {
+	l := New(zap.NewNop().Sugar())
+	type key int
+	ctx := context.Background()
+	// Request contexts usually carry a few values from other middleware.
+	for i := 0; i < 5; i++ {
+		ctx = context.WithValue(ctx, key(i), i)
+	}
+	ctx, _ = l.With(ctx, "request_id", "<request-id-value>")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = l.extractLogger(ctx)
+	}
+}
+
+func TestLogger_InjectedContext(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	ctx, child := l.With(context.Background(), "request_id", "<request-id-value>")
+	cached, ok := loggerFromContext(ctx, l.contextKey())
+	require.True(t, ok)
+	require.Equal(t, child, cached)
+	require.Equal(t, child, ctx.Value(l.contextKey()))
+
+	// Contexts derived from the injected one still find the logger through Value.
+	type key struct{}
+	derived, cancel := context.WithCancel(context.WithValue(ctx, key{}, "value"))
+	defer cancel()
+	require.Equal(t, child, l.extractLogger(derived))
+	require.Equal(t, "value", derived.Value(key{}))
+
+	derived, _ = l.With(derived, "key", "value")
+	l.Info(derived, "something goes here")
+	cancel()
+	require.Error(t, derived.Err())
+	require.Equal(t, `{"level":"info","msg":"something goes here","request_id":"<request-id-value>","key":"value"}`+"\n", buf.String())
+}
+
+func TestLogger_Core(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithRedactedKeys("password"), WithLevel(zapcore.InfoLevel))
+
+	_, child := l.With(context.Background(), "request_id", "<request-id-value>")
+	c := child.Core()
+	require.False(t, c.Enabled(zapcore.DebugLevel))
+
+	require.NoError(t, c.Write(zapcore.Entry{Level: zapcore.InfoLevel, Message: "from a library"}, []zapcore.Field{zap.String("password", "<password-value>")}))
+	zap.New(c).Info("through zap", zap.String("password", "<password-value>"))
+
+	require.Equal(t,
+		`{"level":"info","msg":"from a library","request_id":"<request-id-value>","password":"[REDACTED]"}`+"\n"+
+			`{"level":"info","msg":"through zap","request_id":"<request-id-value>","password":"[REDACTED]"}`+"\n",
+		buf.String(),
+	)
+}
@@ -0,0 +1,172 @@
+package loggytest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ahmedalhulaibi/loggy"
+	"go.uber.org/zap/zapcore"
+)
+
+var _ loggy.Interface = (*Fake)(nil)
+
+// Entry is an entry recorded by Fake.
+type Entry struct {
+	Level   zapcore.Level
+	Message string
+	// Fields are the key/value pairs passed at the log site, encoded as by
+	// zapcore.MapObjectEncoder.
+	Fields map[string]interface{}
+}
+
+// Fake is a loggy.Interface recording the entries logged through it, so tests
+// can assert on them. Like Logger, the Panic methods panic once the entry is
+// recorded; the Fatal methods only record it. It is safe for concurrent use and
+// its zero value is ready to use.
+type Fake struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// Entries returns a copy of the entries recorded so far, in order.
+func (f *Fake) Entries() []Entry {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Entry(nil), f.entries...)
+}
+
+// Reset forgets the entries recorded so far.
+func (f *Fake) Reset() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entries = nil
+}
+
+func (f *Fake) record(lvl zapcore.Level, msg string, keysAndValues []interface{}) {
+	enc := zapcore.NewMapObjectEncoder()
+	for i := 0; i < len(keysAndValues); i++ {
+		switch v := keysAndValues[i].(type) {
+		case zapcore.Field:
+			v.AddTo(enc)
+		case string:
+			if i+1 < len(keysAndValues) {
+				i++
+				enc.Fields[v] = keysAndValues[i]
+			}
+		}
+	}
+
+	f.mu.Lock()
+	f.entries = append(f.entries, Entry{Level: lvl, Message: msg, Fields: enc.Fields})
+	f.mu.Unlock()
+
+	if lvl == zapcore.PanicLevel {
+		panic(msg)
+	}
+}
+
+// Debug records a message at DebugLevel.
+func (f *Fake) Debug(ctx context.Context, args ...interface{}) {
+	f.record(zapcore.DebugLevel, fmt.Sprint(args...), nil)
+}
+
+// Info records a message at InfoLevel.
+func (f *Fake) Info(ctx context.Context, args ...interface{}) {
+	f.record(zapcore.InfoLevel, fmt.Sprint(args...), nil)
+}
+
+// Warn records a message at WarnLevel.
+func (f *Fake) Warn(ctx context.Context, args ...interface{}) {
+	f.record(zapcore.WarnLevel, fmt.Sprint(args...), nil)
+}
+
+// Error records a message at ErrorLevel.
+func (f *Fake) Error(ctx context.Context, args ...interface{}) {
+	f.record(zapcore.ErrorLevel, fmt.Sprint(args...), nil)
+}
+
+// DPanic records a message at DPanicLevel.
+func (f *Fake) DPanic(ctx context.Context, args ...interface{}) {
+	f.record(zapcore.DPanicLevel, fmt.Sprint(args...), nil)
+}
+
+// Panic records a message at PanicLevel, then panics.
+func (f *Fake) Panic(ctx context.Context, args ...interface{}) {
+	f.record(zapcore.PanicLevel, fmt.Sprint(args...), nil)
+}
+
+// Fatal records a message at FatalLevel.
+func (f *Fake) Fatal(ctx context.Context, args ...interface{}) {
+	f.record(zapcore.FatalLevel, fmt.Sprint(args...), nil)
+}
+
+// Debugf records a templated message at DebugLevel.
+func (f *Fake) Debugf(ctx context.Context, template string, args ...interface{}) {
+	f.record(zapcore.DebugLevel, fmt.Sprintf(template, args...), nil)
+}
+
+// Infof records a templated message at InfoLevel.
+func (f *Fake) Infof(ctx context.Context, template string, args ...interface{}) {
+	f.record(zapcore.InfoLevel, fmt.Sprintf(template, args...), nil)
+}
+
+// Warnf records a templated message at WarnLevel.
+func (f *Fake) Warnf(ctx context.Context, template string, args ...interface{}) {
+	f.record(zapcore.WarnLevel, fmt.Sprintf(template, args...), nil)
+}
+
+// Errorf records a templated message at ErrorLevel.
+func (f *Fake) Errorf(ctx context.Context, template string, args ...interface{}) {
+	f.record(zapcore.ErrorLevel, fmt.Sprintf(template, args...), nil)
+}
+
+// DPanicf records a templated message at DPanicLevel.
+func (f *Fake) DPanicf(ctx context.Context, template string, args ...interface{}) {
+	f.record(zapcore.DPanicLevel, fmt.Sprintf(template, args...), nil)
+}
+
+// Panicf records a templated message at PanicLevel, then panics.
+func (f *Fake) Panicf(ctx context.Context, template string, args ...interface{}) {
+	f.record(zapcore.PanicLevel, fmt.Sprintf(template, args...), nil)
+}
+
+// Fatalf records a templated message at FatalLevel.
+func (f *Fake) Fatalf(ctx context.Context, template string, args ...interface{}) {
+	f.record(zapcore.FatalLevel, fmt.Sprintf(template, args...), nil)
+}
+
+// Debugw records a message with key/value pairs at DebugLevel.
+func (f *Fake) Debugw(ctx context.Context, msg string, args ...interface{}) {
+	f.record(zapcore.DebugLevel, msg, args)
+}
+
+// Infow records a message with key/value pairs at InfoLevel.
+func (f *Fake) Infow(ctx context.Context, msg string, args ...interface{}) {
+	f.record(zapcore.InfoLevel, msg, args)
+}
+
+// Warnw records a message with key/value pairs at WarnLevel.
+func (f *Fake) Warnw(ctx context.Context, msg string, args ...interface{}) {
+	f.record(zapcore.WarnLevel, msg, args)
+}
+
+// Errorw records a message with key/value pairs at ErrorLevel.
+func (f *Fake) Errorw(ctx context.Context, msg string, args ...interface{}) {
+	f.record(zapcore.ErrorLevel, msg, args)
+}
+
+// DPanicw records a message with key/value pairs at DPanicLevel.
+func (f *Fake) DPanicw(ctx context.Context, msg string, args ...interface{}) {
+	f.record(zapcore.DPanicLevel, msg, args)
+}
+
+// Panicw records a message with key/value pairs at PanicLevel, then panics.
+func (f *Fake) Panicw(ctx context.Context, msg string, args ...interface{}) {
+	f.record(zapcore.PanicLevel, msg, args)
+}
+
+// Fatalw records a message with key/value pairs at FatalLevel.
+func (f *Fake) Fatalw(ctx context.Context, msg string, args ...interface{}) {
+	f.record(zapcore.FatalLevel, msg, args)
+}
@@ -0,0 +1,37 @@
+package loggytest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFake(t *testing.T) {
+	f := &Fake{}
+	ctx := context.Background()
+
+	f.Info(ctx, "something ", "goes here")
+	f.Warnf(ctx, "retrying in %ds", 5)
+	f.Errorw(ctx, "request failed", "status", 503, zap.String("path", "/orders"))
+
+	require.Equal(t, []Entry{
+		{Level: zapcore.InfoLevel, Message: "something goes here", Fields: map[string]interface{}{}},
+		{Level: zapcore.WarnLevel, Message: "retrying in 5s", Fields: map[string]interface{}{}},
+		{Level: zapcore.ErrorLevel, Message: "request failed", Fields: map[string]interface{}{"status": 503, "path": "/orders"}},
+	}, f.Entries())
+
+	f.Reset()
+	require.Empty(t, f.Entries())
+}
+
+func TestFake_Panic(t *testing.T) {
+	f := &Fake{}
+
+	require.PanicsWithValue(t, "boom", func() { f.Panicw(context.Background(), "boom") })
+	f.Fatal(context.Background(), "does not exit")
+
+	require.Len(t, f.Entries(), 2)
+}
@@ -0,0 +1,40 @@
+// Package loggytest provides helpers for testing code that logs with loggy.
+package loggytest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// GoldenPath returns the path of the golden file of t, which is named after the
+// test, subtests included, in the testdata directory of the package under test.
+func GoldenPath(t *testing.T) string {
+	t.Helper()
+	return filepath.Join("testdata", t.Name()+".golden")
+}
+
+// AssertGolden fails t unless the contents of buf match the golden file of t.
+// When update is true, the golden file is first written with the contents of buf.
+// It is usually called with the value of an -update flag:
+//
+//	var update = flag.Bool("update", false, "update golden files")
+//
+//	loggytest.AssertGolden(t, buf, *update)
+func AssertGolden(t *testing.T, buf *bytes.Buffer, update bool) {
+	t.Helper()
+	path := GoldenPath(t)
+
+	if update {
+		t.Log("Updating golden file:", path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0644))
+	}
+
+	golden, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(golden), buf.String())
+}
@@ -0,0 +1,51 @@
+package loggytest
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ahmedalhulaibi/loggy"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+func TestGoldenPath(t *testing.T) {
+	t.Run("subtest", func(t *testing.T) {
+		require.Equal(t, filepath.Join("testdata", "TestGoldenPath", "subtest.golden"), GoldenPath(t))
+	})
+}
+
+func TestAssertGolden(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	encoderCfg := zapcore.EncoderConfig{
+		MessageKey:  "msg",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	}
+	l := loggy.New(zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderCfg), zapcore.AddSync(buf), zap.DebugLevel)).Sugar())
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	l.Infow(ctx, "something goes here", "key", "value")
+
+	AssertGolden(t, buf, *updateGolden)
+}
+
+func TestAssertGolden_Update(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	t.Cleanup(func() { require.NoError(t, os.Chdir(wd)) })
+
+	AssertGolden(t, bytes.NewBufferString("something goes here\n"), true)
+
+	golden, err := os.ReadFile(GoldenPath(t))
+	require.NoError(t, err)
+	require.Equal(t, "something goes here\n", string(golden))
+}
@@ -0,0 +1,47 @@
+package loggytest
+
+import (
+	"bytes"
+	"sync"
+)
+
+// SafeBuffer is a bytes.Buffer safe for concurrent use. It implements
+// zapcore.WriteSyncer, so loggers writing from many goroutines can share it
+// in tests.
+type SafeBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// Write appends p to the buffer.
+func (b *SafeBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+// Sync does nothing, since the buffer is in memory.
+func (b *SafeBuffer) Sync() error {
+	return nil
+}
+
+// Bytes returns a copy of the contents of the buffer.
+func (b *SafeBuffer) Bytes() []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]byte(nil), b.buf.Bytes()...)
+}
+
+// String returns the contents of the buffer as a string.
+func (b *SafeBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+// Reset empties the buffer.
+func (b *SafeBuffer) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf.Reset()
+}
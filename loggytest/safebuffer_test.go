@@ -0,0 +1,43 @@
+package loggytest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ahmedalhulaibi/loggy"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestSafeBuffer_ConcurrentWrites(t *testing.T) {
+	buf := &SafeBuffer{}
+	encoderCfg := zapcore.EncoderConfig{
+		MessageKey:  "msg",
+		LevelKey:    "level",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	}
+	l := loggy.New(zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderCfg), buf, zap.DebugLevel)).Sugar())
+
+	const goroutines, entries = 8, 100
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx, _ := l.With(context.Background(), "goroutine", i)
+			for j := 0; j < entries; j++ {
+				l.Infow(ctx, "something goes here", "entry", j)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, goroutines*entries)
+
+	buf.Reset()
+	require.Empty(t, buf.Bytes())
+}
@@ -0,0 +1,63 @@
+package loggy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrNoLoggerInContext is returned by RequireLoggerInContext when ctx does not carry a Logger.
+var ErrNoLoggerInContext = errors.New("loggy: no logger in context")
+
+// RequireLoggerInContext returns ErrNoLoggerInContext if ctx does not carry a
+// Logger injected by With. Handlers can use it to assert that their logging
+// middleware ran, for example in development or in tests. Loggers configured
+// with WithContextKeyNamespace are not looked for.
+func RequireLoggerInContext(ctx context.Context) error {
+	if _, ok := loggerFromContext(ctx, loggerctxkey); !ok {
+		return ErrNoLoggerInContext
+	}
+	return nil
+}
+
+// WithMissingLoggerWarning logs a warning when an entry is logged with a context
+// that does not carry a Logger, so the receiver is used instead. This usually
+// means a middleware that injects the logger is missing. At most one warning is
+// logged per interval.
+func WithMissingLoggerWarning(interval time.Duration) Option {
+	return func(o *options) {
+		o.missingLoggerWarningInterval = interval
+	}
+}
+
+func (r *root) warnMissingLogger(s *zap.SugaredLogger) {
+	interval := r.options().missingLoggerWarningInterval
+	if interval <= 0 {
+		return
+	}
+	if !allowWarning(&r.lastMissingLoggerWarning, interval) {
+		return
+	}
+	s.Warn("loggy: no logger in context, falling back to the receiver")
+}
+
+// orphanKey is the field key marking entries logged without a logger in the context.
+const orphanKey = "no_request_context"
+
+// WithOrphanTag marks every entry logged with a context that does not carry a
+// Logger with the no_request_context field, so the call sites missing a
+// middleware can be found and alerted on from the logs themselves.
+func WithOrphanTag() Option {
+	return func(o *options) {
+		o.orphanTag = true
+	}
+}
+
+func (r *root) tagOrphan(s *zap.SugaredLogger) *zap.SugaredLogger {
+	if !r.options().orphanTag {
+		return s
+	}
+	return s.With(orphanKey, true)
+}
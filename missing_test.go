@@ -0,0 +1,55 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRequireLoggerInContext(t *testing.T) {
+	l := New(zap.NewNop().Sugar())
+
+	require.ErrorIs(t, RequireLoggerInContext(context.Background()), ErrNoLoggerInContext)
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	require.NoError(t, RequireLoggerInContext(ctx))
+}
+
+func TestWithMissingLoggerWarning(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithMissingLoggerWarning(time.Hour))
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	l.Info(ctx, "injected")
+	l.Info(context.Background(), "first fallback")
+	l.Info(context.Background(), "second fallback")
+
+	require.Equal(t,
+		`{"level":"info","msg":"injected","request_id":"<request-id-value>"}`+"\n"+
+			`{"level":"warn","msg":"loggy: no logger in context, falling back to the receiver"}`+"\n"+
+			`{"level":"info","msg":"first fallback"}`+"\n"+
+			`{"level":"info","msg":"second fallback"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestWithOrphanTag(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithOrphanTag())
+
+	l.Infow(context.Background(), "orphaned", "key", "value")
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	l.Infow(ctx, "injected", "key", "value")
+
+	require.Equal(t,
+		`{"level":"info","msg":"orphaned","no_request_context":true,"key":"value"}`+"\n"+
+			`{"level":"info","msg":"injected","request_id":"<request-id-value>","key":"value"}`+"\n",
+		buf.String(),
+	)
+}
@@ -0,0 +1,46 @@
+package loggy
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Field keys marking entries downgraded by WithNoPanicFatal.
+const (
+	wouldPanicKey = "would_panic"
+	wouldExitKey  = "would_exit"
+)
+
+// WithNoPanicFatal makes entries at PanicLevel and FatalLevel log at ErrorLevel
+// instead of panicking or exiting, so a library embedding loggy never crashes its
+// host application. It applies to every method taking a level, such as Printf,
+// LogContext and DumpGoroutines, as well as to the Panic and Fatal methods. The
+// entries are marked with the would_panic or would_exit field. LogAt and Replay,
+// which never panic nor exit, keep the level of their entries.
+func WithNoPanicFatal() Option {
+	return func(o *options) {
+		o.noPanicFatal = true
+	}
+}
+
+// downgrade returns the logger to write an entry at lvl with at ErrorLevel, when
+// lvl is PanicLevel or FatalLevel and s is configured with WithNoPanicFatal. The
+// logger marks the entry with would_panic or would_exit. zap panics or exits
+// after writing such entries whatever its core does, so every method logging at
+// one of these levels must go through downgrade before picking the zap method.
+func downgrade(s *zap.SugaredLogger, lvl zapcore.Level) (*zap.SugaredLogger, bool) {
+	var key string
+	switch lvl {
+	case zapcore.PanicLevel:
+		key = wouldPanicKey
+	case zapcore.FatalLevel:
+		key = wouldExitKey
+	default:
+		return nil, false
+	}
+	c, ok := s.Desugar().Core().(*core)
+	if !ok || !c.root.options().noPanicFatal {
+		return nil, false
+	}
+	return s.With(key, true), true
+}
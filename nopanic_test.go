@@ -0,0 +1,125 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithNoPanicFatal(t *testing.T) {
+	tests := map[string]struct {
+		log      func(ctx context.Context, l Logger)
+		expected string
+	}{
+		"Should downgrade Panic": {
+			log:      func(ctx context.Context, l Logger) { l.Panic(ctx, "something goes here") },
+			expected: `{"level":"error","msg":"something goes here","request_id":"<request-id-value>","would_panic":true}`,
+		},
+		"Should downgrade Panicf": {
+			log:      func(ctx context.Context, l Logger) { l.Panicf(ctx, "something goes %s", "here") },
+			expected: `{"level":"error","msg":"something goes here","request_id":"<request-id-value>","would_panic":true}`,
+		},
+		"Should downgrade Panicw": {
+			log:      func(ctx context.Context, l Logger) { l.Panicw(ctx, "something goes here", "key", "value") },
+			expected: `{"level":"error","msg":"something goes here","request_id":"<request-id-value>","would_panic":true,"key":"value"}`,
+		},
+		"Should downgrade Fatal": {
+			log:      func(ctx context.Context, l Logger) { l.Fatal(ctx, "something goes here") },
+			expected: `{"level":"error","msg":"something goes here","request_id":"<request-id-value>","would_exit":true}`,
+		},
+		"Should downgrade Fatalf": {
+			log:      func(ctx context.Context, l Logger) { l.Fatalf(ctx, "something goes %s", "here") },
+			expected: `{"level":"error","msg":"something goes here","request_id":"<request-id-value>","would_exit":true}`,
+		},
+		"Should downgrade Fatalw": {
+			log:      func(ctx context.Context, l Logger) { l.Fatalw(ctx, "something goes here", "key", "value") },
+			expected: `{"level":"error","msg":"something goes here","request_id":"<request-id-value>","would_exit":true,"key":"value"}`,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithNoPanicFatal())
+
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+			require.NotPanics(t, func() { tc.log(ctx, l) })
+
+			require.Equal(t, tc.expected+"\n", buf.String())
+		})
+	}
+}
+
+func TestWithNoPanicFatal_LevelMethods(t *testing.T) {
+	tests := map[string]struct {
+		log         func(ctx context.Context, l Logger)
+		expectedMsg string
+		expectedKey string
+	}{
+		"Should downgrade Printf at PanicLevel": {
+			log:         func(ctx context.Context, l Logger) { l.Printf(ctx, zapcore.PanicLevel)("something goes %s", "here") },
+			expectedMsg: "something goes here",
+			expectedKey: wouldPanicKey,
+		},
+		"Should downgrade Printf at FatalLevel": {
+			log:         func(ctx context.Context, l Logger) { l.Printf(ctx, zapcore.FatalLevel)("something goes %s", "here") },
+			expectedMsg: "something goes here",
+			expectedKey: wouldExitKey,
+		},
+		"Should downgrade LogContext at PanicLevel": {
+			log:         func(ctx context.Context, l Logger) { l.LogContext(ctx, zapcore.PanicLevel, "context dump") },
+			expectedMsg: "context dump",
+			expectedKey: wouldPanicKey,
+		},
+		"Should downgrade LogContext at FatalLevel": {
+			log:         func(ctx context.Context, l Logger) { l.LogContext(ctx, zapcore.FatalLevel, "context dump") },
+			expectedMsg: "context dump",
+			expectedKey: wouldExitKey,
+		},
+		"Should downgrade DumpGoroutines at PanicLevel": {
+			log:         func(ctx context.Context, l Logger) { l.DumpGoroutines(ctx, zapcore.PanicLevel) },
+			expectedMsg: "goroutine dump",
+			expectedKey: wouldPanicKey,
+		},
+		"Should downgrade DumpGoroutines at FatalLevel": {
+			log:         func(ctx context.Context, l Logger) { l.DumpGoroutines(ctx, zapcore.FatalLevel) },
+			expectedMsg: "goroutine dump",
+			expectedKey: wouldExitKey,
+		},
+		"Should downgrade JSONLineWriter lines at PanicLevel": {
+			log: func(ctx context.Context, l Logger) {
+				_, _ = l.JSONLineWriter(ctx).Write([]byte(`{"level":"panic","msg":"from subprocess"}` + "\n"))
+			},
+			expectedMsg: "from subprocess",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithNoPanicFatal())
+
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+			require.NotPanics(t, func() { tc.log(ctx, l) })
+
+			lines := decodeLines(t, buf)
+			require.Len(t, lines, 1)
+			require.Equal(t, "error", lines[0]["level"])
+			require.Equal(t, tc.expectedMsg, lines[0]["msg"])
+			require.Equal(t, "<request-id-value>", lines[0]["request_id"])
+			if tc.expectedKey != "" {
+				require.Equal(t, true, lines[0][tc.expectedKey])
+			}
+		})
+	}
+}
+
+func TestLogger_PanicWithoutNoPanicFatal(t *testing.T) {
+	l := New(newZapTestLogger(t, zapcore.AddSync(bytes.NewBuffer([]byte{}))).Sugar())
+
+	ctx, _ := l.With(context.Background())
+	require.Panics(t, func() { l.Panicw(ctx, "something goes here") })
+}
@@ -0,0 +1,174 @@
+package loggy
+
+import (
+	"context"
+	"os"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Option configures a Logger. Options are applied by New and by Reconfigure.
+type Option func(*options)
+
+type options struct {
+	// fields are attached to every entry written by the Logger.
+	fields []zapcore.Field
+	// levelFields are attached to the entries at their level.
+	levelFields map[zapcore.Level][]zapcore.Field
+	// level is the minimum level enabled on top of the underlying core's level.
+	level zapcore.LevelEnabler
+	// levelsByName overrides level for loggers whose name has one of its keys as a prefix.
+	levelsByName map[string]zapcore.Level
+	// minNamedLevel is the lowest level in levelsByName.
+	minNamedLevel zapcore.Level
+	// sampling caps the entries written with the same level and message.
+	sampling *samplingConfig
+	// samplingHook observes the decisions of sampling.
+	samplingHook func(ent zapcore.Entry, sampled bool)
+	// levelRateLimits caps the entries written per second at each level.
+	levelRateLimits map[zapcore.Level]int
+	// redactedKeys are the field keys whose values are masked.
+	redactedKeys map[string]struct{}
+	// redactionEnabled reports whether redactedKeys are enforced.
+	redactionEnabled func() bool
+	// encryptedKeys maps the field keys whose values are encrypted to the function
+	// encrypting them.
+	encryptedKeys map[string]func([]byte) ([]byte, error)
+	// fieldAliases maps alias keys to their canonical key.
+	fieldAliases map[string]string
+	// duplicateKeyPolicy resolves keys set more than once on an entry.
+	duplicateKeyPolicy DuplicateKeyPolicy
+	// maxArrayLen is the number of values the Array policy collects for a key.
+	maxArrayLen int
+	// mutedLevels are dropped regardless of the core's minimum level.
+	mutedLevels levelSet
+	// extractors compute fields from the context at the log site.
+	extractors []func(ctx context.Context) []interface{}
+	// missingLoggerWarningInterval rate limits the warning logged when a context
+	// does not carry a logger. Zero disables the warning.
+	missingLoggerWarningInterval time.Duration
+	// orphanTag marks entries logged with a context that does not carry a logger.
+	orphanTag bool
+	// contextKeyNamespace isolates the context key of the logger.
+	contextKeyNamespace string
+	// summaryPassthrough is the level from which summarized entries are still written.
+	summaryPassthrough *zapcore.Level
+	// messageValidator rejects the messages that are not approved.
+	messageValidator func(msg string) error
+	// nameFromField is the key of the field entries are named after.
+	nameFromField string
+	// deadlinePressure is the fraction of the time budget of a context under
+	// which its Debug and Info entries are escalated.
+	deadlinePressure float64
+	// entryCap is the number of entries below ErrorLevel written per request.
+	entryCap int
+	// messageCounter counts the entries written with each level and message.
+	messageCounter bool
+	// keyValidator rejects the field keys that do not follow the naming convention.
+	keyValidator func(key string) error
+	// fieldChangeTracing logs the keys added by every call to With.
+	fieldChangeTracing bool
+	// structuredSingleArg logs a single struct or map argument as fields.
+	structuredSingleArg bool
+	// maxBytes is the number of bytes encoded by Bytes fields.
+	maxBytes int
+	// noPanicFatal logs Panic and Fatal entries at ErrorLevel instead.
+	noPanicFatal bool
+	// ipMasking masks IPv4 and IPv6 fields to the prefixes of ipMaskV4Bits and ipMaskV6Bits bits.
+	ipMasking    bool
+	ipMaskV4Bits int
+	ipMaskV6Bits int
+	// stackMaxFrames is the number of frames kept in captured stacks.
+	stackMaxFrames int
+	// maxFieldDepth limits how deeply values encoded by reflection are nested.
+	maxFieldDepth int
+	// timeValueLayout formats the time.Time field values.
+	timeValueLayout string
+	// entryUUID adds a unique ID to every entry.
+	entryUUID bool
+	// callerFunction adds the function that logged every entry.
+	callerFunction bool
+	// writeErrorHandler handles the errors of writing and syncing entries.
+	writeErrorHandler func(error)
+	// auditCore writes the entries logged with Audit when set.
+	auditCore zapcore.Core
+	// encoderConfig replaces the default encoder config of the constructors.
+	encoderConfig *zapcore.EncoderConfig
+	// levelEncoder replaces the level encoder of the encoder config.
+	levelEncoder zapcore.LevelEncoder
+	// callerEncoder replaces the caller encoder of the encoder config.
+	callerEncoder zapcore.CallerEncoder
+	// colorKey is the key of the field whose value picks the color of lines in colors.
+	colorKey string
+	colors   map[string]Color
+	// linePrefix and lineSuffix frame every line written by the constructors.
+	linePrefix string
+	lineSuffix string
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// hostname is swapped in tests to observe how often the hostname is resolved.
+var hostname = os.Hostname
+
+// WithHostInfo attaches the host name and process ID to every entry as the
+// hostname and pid fields. Both are resolved once when the Logger is constructed.
+// If the host name cannot be resolved it is logged as unknown.
+func WithHostInfo() Option {
+	return func(o *options) {
+		host, err := hostname()
+		if err != nil {
+			host = "unknown"
+		}
+		o.fields = append(o.fields, zap.String("hostname", host), zap.Int("pid", os.Getpid()))
+	}
+}
+
+// WithEnvFields attaches the values of environment variables to every entry, such
+// as the pod name Kubernetes exposes through the downward API. fields maps the
+// name of each variable to the key of its field. The variables are read once,
+// when the Logger is constructed; unset ones are skipped. Fields are attached in
+// the order of the variable names.
+func WithEnvFields(fields map[string]string) Option {
+	return func(o *options) {
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if v, ok := os.LookupEnv(name); ok {
+				o.fields = append(o.fields, zap.String(fields[name], v))
+			}
+		}
+	}
+}
+
+// WithSchemaVersion attaches v to every entry as the log_schema_version field,
+// so consumers can tell apart entries written before and after a change to the
+// fields, such as a renamed key, and parse each accordingly.
+func WithSchemaVersion(v string) Option {
+	return func(o *options) {
+		o.fields = append(o.fields, zap.String("log_schema_version", v))
+	}
+}
+
+// WithContextKeyNamespace stores the Logger, and every logger derived from it, in
+// a context under a key of its own, named after ns. A library can use it so that
+// its logger and the one of the host application coexist in the same context,
+// each finding its own. The namespace is fixed when the Logger is constructed;
+// Reconfigure does not change it.
+func WithContextKeyNamespace(ns string) Option {
+	return func(o *options) {
+		o.contextKeyNamespace = ns
+	}
+}
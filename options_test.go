@@ -0,0 +1,108 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithHostInfo(t *testing.T) {
+	calls := 0
+	hostname = func() (string, error) {
+		calls++
+		return "<hostname-value>", nil
+	}
+	t.Cleanup(func() { hostname = os.Hostname })
+
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithHostInfo())
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	l.Info(ctx, "first")
+	l.Info(context.Background(), "second")
+	l.Infow(ctx, "third", "key", "value")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		require.Equal(t, "<hostname-value>", line["hostname"])
+		require.Equal(t, float64(os.Getpid()), line["pid"])
+	}
+	require.Equal(t, 1, calls)
+}
+
+func TestWithEnvFields(t *testing.T) {
+	setenv(t, "LOGGY_TEST_POD_NAME", "<pod-name-value>")
+	setenv(t, "LOGGY_TEST_NODE_NAME", "<node-name-value>")
+
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithEnvFields(map[string]string{
+		"LOGGY_TEST_POD_NAME":  "pod_name",
+		"LOGGY_TEST_NODE_NAME": "node_name",
+		"LOGGY_TEST_NAMESPACE": "namespace",
+	}))
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	l.Info(ctx, "something goes here")
+
+	require.Equal(t, `{"level":"info","msg":"something goes here","node_name":"<node-name-value>","pod_name":"<pod-name-value>","request_id":"<request-id-value>"}`+"\n", buf.String())
+}
+
+func TestWithSchemaVersion(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithSchemaVersion("2"))
+
+	ctx, child := l.With(context.Background(), "request_id", "<request-id-value>")
+	named, grandchild := child.Named(ctx, "app")
+	l.Info(context.Background(), "parent")
+	child.Info(ctx, "child")
+	grandchild.Infow(named, "grandchild", "key", "value")
+
+	require.Equal(t,
+		`{"level":"info","msg":"parent","log_schema_version":"2"}`+"\n"+
+			`{"level":"info","msg":"child","log_schema_version":"2","request_id":"<request-id-value>"}`+"\n"+
+			`{"level":"info","logger":"app","msg":"grandchild","log_schema_version":"2","request_id":"<request-id-value>","key":"value"}`+"\n",
+		buf.String(),
+	)
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var lines []map[string]interface{}
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		line := map[string]interface{}{}
+		require.NoError(t, dec.Decode(&line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestWithContextKeyNamespace(t *testing.T) {
+	appBuf := bytes.NewBuffer([]byte{})
+	app := New(newZapTestLogger(t, zapcore.AddSync(appBuf)).Sugar())
+	libBuf := bytes.NewBuffer([]byte{})
+	lib := New(newZapTestLogger(t, zapcore.AddSync(libBuf)).Sugar(), WithContextKeyNamespace("lib"))
+
+	ctx, _ := app.With(context.Background(), "request_id", "<request-id-value>")
+	ctx, _ = lib.With(ctx, "component", "<component-value>")
+
+	app.Info(ctx, "from app")
+	lib.Info(ctx, "from lib")
+
+	require.Equal(t, `{"level":"info","msg":"from app","request_id":"<request-id-value>"}`+"\n", appBuf.String())
+	require.Equal(t, `{"level":"info","msg":"from lib","component":"<component-value>"}`+"\n", libBuf.String())
+}
+
+// setenv sets the environment variable key to value for the duration of t,
+// like t.Setenv, which needs Go 1.17.
+func setenv(t *testing.T, key, value string) {
+	t.Helper()
+	require.NoError(t, os.Setenv(key, value))
+	t.Cleanup(func() { require.NoError(t, os.Unsetenv(key)) })
+}
@@ -0,0 +1,69 @@
+package loggy
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewPrettyConsole creates a Logger that writes JSON entries at level and above
+// to ws, indented over several lines so they are easy to read while developing
+// locally. It uses zap's production encoder config, with ISO8601 times, unless
+// WithEncoderConfig is given. Indenting every entry is costly, so a warning is
+// logged when ws is a file or pipe rather than a terminal, which suggests
+// production volumes.
+func NewPrettyConsole(ws zapcore.WriteSyncer, level zapcore.Level, opts ...Option) Logger {
+	cfg := zap.NewProductionEncoderConfig()
+	cfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	l := build(newPrettyEncoder, cfg, ws, level, newOptions(opts...))
+	if f, ok := ws.(*os.File); ok && !isTerminal(f) {
+		l.s.Warnw("loggy: NewPrettyConsole is meant for local development, not for production sinks", "sink", f.Name())
+	}
+	return l
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+var prettyPool = buffer.NewPool()
+
+// prettyEncoder indents the entries encoded by a JSON encoder.
+type prettyEncoder struct {
+	zapcore.Encoder
+	lineEnding string
+}
+
+func newPrettyEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	lineEnding := cfg.LineEnding
+	if lineEnding == "" {
+		lineEnding = zapcore.DefaultLineEnding
+	}
+	return prettyEncoder{Encoder: zapcore.NewJSONEncoder(cfg), lineEnding: lineEnding}
+}
+
+func (enc prettyEncoder) Clone() zapcore.Encoder {
+	return prettyEncoder{Encoder: enc.Encoder.Clone(), lineEnding: enc.lineEnding}
+}
+
+func (enc prettyEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line, err := enc.Encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return nil, err
+	}
+	defer line.Free()
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, bytes.TrimSuffix(line.Bytes(), []byte(enc.lineEnding)), "", "  "); err != nil {
+		return nil, err
+	}
+	buf := prettyPool.Get()
+	buf.Write(indented.Bytes())
+	buf.AppendString(enc.lineEnding)
+	return buf, nil
+}
@@ -0,0 +1,49 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewPrettyConsole(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := NewPrettyConsole(zapcore.AddSync(buf), zapcore.DebugLevel, WithEncoderConfig(zapcore.EncoderConfig{
+		MessageKey:  "msg",
+		LevelKey:    "level",
+		NameKey:     "logger",
+		EncodeLevel: zapcore.LowercaseLevelEncoder,
+	}))
+
+	ctx, _ := l.Named(context.Background(), "app")
+	ctx, _ = l.With(ctx, "request_id", "<request-id-value>")
+	l.Infow(ctx, "something goes here", "http", map[string]interface{}{"status": 200, "hops": []string{"edge", "origin"}})
+	l.Warn(ctx, "slow query")
+
+	if *updateGolden {
+		t.Log("Updating golden file:", goldenFilename(t))
+		require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+		require.NoError(t, os.WriteFile(goldenFilename(t), buf.Bytes(), 0644))
+	}
+
+	golden, err := os.ReadFile(goldenFilename(t))
+	require.NoError(t, err)
+	require.Equal(t, buf.Bytes(), golden)
+}
+
+func TestNewPrettyConsole_WarnsOnFile(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "app.log"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	NewPrettyConsole(f, zapcore.InfoLevel, WithEncoderConfig(zapcore.EncoderConfig{MessageKey: "msg"}))
+
+	out, err := os.ReadFile(f.Name())
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"msg": "loggy: NewPrettyConsole is meant for local development, not for production sinks"`)
+}
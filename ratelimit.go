@@ -0,0 +1,124 @@
+package loggy
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithLevelRateLimit caps the entries written at level to perSecond per second,
+// across every logger derived from the same Logger, and drops the excess. Unlike
+// muting or sampling, it only engages during bursts: the cap is enforced with a
+// token bucket holding up to perSecond tokens. A warning reports the number of
+// dropped entries in the rate_limited field and their level in the
+// rate_limited_level field, once the level is allowed again or a second after
+// the first entry dropped since the last report, whichever comes first, so a
+// burst followed by silence is still reported.
+// A perSecond of zero or less removes the cap for level.
+func WithLevelRateLimit(level zapcore.Level, perSecond int) Option {
+	return func(o *options) {
+		if perSecond <= 0 {
+			delete(o.levelRateLimits, level)
+			return
+		}
+		if o.levelRateLimits == nil {
+			o.levelRateLimits = map[zapcore.Level]int{}
+		}
+		o.levelRateLimits[level] = perSecond
+	}
+}
+
+// rateLimit reports whether ent is within the rate limit of its level, and
+// reports the entries dropped before it.
+func (c *core) rateLimit(ent zapcore.Entry) bool {
+	perSecond, ok := c.root.options().levelRateLimits[ent.Level]
+	if !ok {
+		return true
+	}
+	allowed, dropped, schedule := c.root.limiter.allow(ent, perSecond)
+	if dropped > 0 {
+		c.warnRateLimited(ent, dropped)
+	}
+	if schedule {
+		afterFunc(rateLimitReportInterval, func() {
+			if dropped := c.root.limiter.takeDropped(ent.Level); dropped > 0 {
+				c.warnRateLimited(zapcore.Entry{Level: ent.Level, Time: now(), LoggerName: ent.LoggerName}, dropped)
+			}
+		})
+	}
+	return allowed
+}
+
+// rateLimitReportInterval is the longest an entry dropped by a rate limit waits
+// to be reported.
+const rateLimitReportInterval = time.Second
+
+func (c *core) warnRateLimited(ent zapcore.Entry, dropped uint64) {
+	c.warn(ent, "loggy: rate limited entries", zap.Stringer("rate_limited_level", ent.Level), zap.Uint64("rate_limited", dropped))
+}
+
+// rateLimiter holds a token bucket per rate limited level.
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[zapcore.Level]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	// dropped is the number of entries dropped since the last report.
+	dropped uint64
+	// scheduled is set while a report of the dropped entries is scheduled.
+	scheduled bool
+}
+
+// allow takes a token for ent from the bucket of its level, refilled according
+// to the time of ent. When ent is allowed, it also returns the number of entries
+// dropped since the last report. When ent is the first entry dropped since a
+// report was last scheduled, it reports that one is to be scheduled.
+func (l *rateLimiter) allow(ent zapcore.Entry, perSecond int) (allowed bool, dropped uint64, schedule bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[ent.Level]
+	if !ok {
+		if l.buckets == nil {
+			l.buckets = map[zapcore.Level]*tokenBucket{}
+		}
+		b = &tokenBucket{tokens: float64(perSecond), last: ent.Time}
+		l.buckets[ent.Level] = b
+	}
+	if elapsed := ent.Time.Sub(b.last); elapsed > 0 {
+		b.tokens = math.Min(float64(perSecond), b.tokens+elapsed.Seconds()*float64(perSecond))
+		b.last = ent.Time
+	}
+	if b.tokens < 1 {
+		b.dropped++
+		schedule = !b.scheduled
+		b.scheduled = true
+		return false, 0, schedule
+	}
+	b.tokens--
+	dropped = b.dropped
+	b.dropped = 0
+	return true, dropped, false
+}
+
+// takeDropped returns the number of entries at level dropped since the last
+// report, and resets it for the next scheduled report.
+func (l *rateLimiter) takeDropped(level zapcore.Level) uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[level]
+	if !ok {
+		return 0
+	}
+	dropped := b.dropped
+	b.dropped = 0
+	b.scheduled = false
+	return dropped
+}
@@ -0,0 +1,92 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// manualClock is a zapcore.Clock that only moves when advanced.
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time { return c.now }
+
+func (c *manualClock) NewTicker(d time.Duration) *time.Ticker { return time.NewTicker(d) }
+
+// deferAfterFuncs swaps afterFunc for the duration of t with one that queues f
+// instead of calling it after its delay, and returns the queue.
+func deferAfterFuncs(t *testing.T) *[]func() {
+	t.Helper()
+	realAfterFunc := afterFunc
+	t.Cleanup(func() { afterFunc = realAfterFunc })
+	var queued []func()
+	afterFunc = func(d time.Duration, f func()) func() bool {
+		queued = append(queued, f)
+		return func() bool { return true }
+	}
+	return &queued
+}
+
+func TestWithLevelRateLimit(t *testing.T) {
+	deferAfterFuncs(t)
+	buf := bytes.NewBuffer([]byte{})
+	clock := &manualClock{now: time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf), zap.WithClock(clock)).Sugar(), WithLevelRateLimit(zapcore.ErrorLevel, 2))
+
+	ctx, _ := l.With(context.Background())
+	for i := 0; i < 5; i++ {
+		l.Errorw(ctx, "burst", "i", i)
+		l.Infow(ctx, "unlimited", "i", i)
+	}
+
+	lines := decodeLines(t, buf)
+	var errors int
+	for _, line := range lines {
+		if line["level"] == "error" {
+			errors++
+		}
+	}
+	require.Equal(t, 2, errors)
+	require.Len(t, lines, 7)
+
+	buf.Reset()
+	clock.now = clock.now.Add(time.Second)
+	l.Errorw(ctx, "after burst")
+
+	require.Equal(t,
+		`{"level":"warn","msg":"loggy: rate limited entries","rate_limited_level":"error","rate_limited":3}`+"\n"+
+			`{"level":"error","msg":"after burst"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestWithLevelRateLimit_BurstEnds(t *testing.T) {
+	queued := deferAfterFuncs(t)
+	buf := bytes.NewBuffer([]byte{})
+	clock := &manualClock{now: time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf), zap.WithClock(clock)).Sugar(), WithLevelRateLimit(zapcore.ErrorLevel, 2))
+
+	ctx, _ := l.With(context.Background())
+	for i := 0; i < 5; i++ {
+		l.Errorw(ctx, "burst", "i", i)
+	}
+	require.Len(t, *queued, 1)
+
+	buf.Reset()
+	(*queued)[0]()
+	require.Equal(t, `{"level":"warn","msg":"loggy: rate limited entries","rate_limited_level":"error","rate_limited":3}`+"\n", buf.String())
+
+	// The next dropped entry schedules a new report.
+	buf.Reset()
+	l.Errorw(ctx, "burst", "i", 5)
+	require.Len(t, *queued, 2)
+	(*queued)[1]()
+	require.Equal(t, `{"level":"warn","msg":"loggy: rate limited entries","rate_limited_level":"error","rate_limited":1}`+"\n", buf.String())
+}
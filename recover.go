@@ -0,0 +1,83 @@
+package loggy
+
+import (
+	"context"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Fielder is implemented by values that describe themselves as key/value pairs.
+// A recovered panic value implementing it contributes its fields to the entry.
+type Fielder interface {
+	Fields() []interface{}
+}
+
+// Recover recovers a panic in the calling goroutine and logs it at ErrorLevel
+// with the stack in the stack field. It must be deferred directly:
+//
+//	defer l.Recover(ctx)
+//
+// A panic value implementing error is logged in the error field, including its
+// verbose form when it has one, and a value implementing Fielder contributes its
+// fields. Any other value is logged in the panic field.
+func (l Logger) Recover(ctx context.Context) {
+	if v := recover(); v != nil {
+		if s, ok := l.sugarAt(ctx, zapcore.ErrorLevel); ok {
+			logRecovered(s, panicFields(v, debug.Stack()))
+		}
+	}
+}
+
+func panicFields(v interface{}, stack []byte) []interface{} {
+	var args []interface{}
+	err, isErr := v.(error)
+	if isErr {
+		args = append(args, zap.Error(err))
+	}
+	if f, ok := v.(Fielder); ok {
+		args = append(args, f.Fields()...)
+	}
+	if !isErr {
+		args = append(args, zap.Any("panic", v))
+	}
+	return append(args, zap.ByteString("stack", stack))
+}
+
+// logRecovered logs a recovered panic at ErrorLevel with args, like Errorw, but
+// with the site of the panic as its caller, if the logger records callers. It
+// must be called by the function deferred to recover the panic.
+func logRecovered(s *zap.SugaredLogger, args []interface{}) {
+	ce := s.With(args...).Desugar().Check(zapcore.ErrorLevel, "recovered from panic")
+	if ce == nil {
+		return
+	}
+	if caller, ok := panicCaller(); ok && ce.Caller.Defined {
+		ce.Caller = caller
+	}
+	ce.Write()
+}
+
+// panicCaller returns the function that panicked, found on the stack of the
+// function recovering the panic as the first frame past those of the runtime
+// that follow runtime.gopanic.
+func panicCaller() (zapcore.EntryCaller, bool) {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	panicking := false
+	for {
+		f, more := frames.Next()
+		switch {
+		case f.Function == "runtime.gopanic":
+			panicking = true
+		case panicking && !strings.HasPrefix(f.Function, "runtime."):
+			return zapcore.EntryCaller{Defined: true, PC: f.PC, File: f.File, Line: f.Line, Function: f.Function}, true
+		}
+		if !more {
+			return zapcore.EntryCaller{}, false
+		}
+	}
+}
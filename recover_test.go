@@ -0,0 +1,84 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+type orderError struct {
+	OrderID string
+	Status  int
+}
+
+func (e orderError) Error() string {
+	return "order failed"
+}
+
+func (e orderError) Fields() []interface{} {
+	return []interface{}{"order_id", e.OrderID, "status", e.Status}
+}
+
+func TestLogger_Recover(t *testing.T) {
+	tests := map[string]struct {
+		value interface{}
+		want  map[string]interface{}
+	}{
+		"Should log a custom error structurally": {
+			value: orderError{OrderID: "<order-id-value>", Status: 409},
+			want: map[string]interface{}{
+				"error":    "order failed",
+				"order_id": "<order-id-value>",
+				"status":   float64(409),
+			},
+		},
+		"Should log any other value in the panic field": {
+			value: "boom",
+			want: map[string]interface{}{
+				"panic": "boom",
+			},
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+
+			require.NotPanics(t, func() {
+				defer l.Recover(ctx)
+				panic(tc.value)
+			})
+
+			lines := decodeLines(t, buf)
+			require.Len(t, lines, 1)
+			line := lines[0]
+			require.Equal(t, "error", line["level"])
+			require.Equal(t, "recovered from panic", line["msg"])
+			require.Equal(t, "<request-id-value>", line["request_id"])
+			require.Contains(t, line["stack"], "TestLogger_Recover")
+			for k, v := range tc.want {
+				require.Equal(t, v, line[k], k)
+			}
+		})
+	}
+}
+
+func TestLogger_Recover_Caller(t *testing.T) {
+	l, logs := newCallerTestLogger(t)
+
+	var file string
+	var line int
+	func() {
+		defer l.Recover(context.Background())
+		_, file, line, _ = runtime.Caller(0)
+		panic("boom")
+	}()
+
+	requireCaller(t, logs, file, line+1)
+}
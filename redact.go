@@ -0,0 +1,45 @@
+package loggy
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// redactedValue replaces the value of every redacted field.
+const redactedValue = "[REDACTED]"
+
+// WithRedactedKeys masks the value of any field whose key is one of keys,
+// whether the field was added with With or passed at the log site.
+func WithRedactedKeys(keys ...string) Option {
+	return func(o *options) {
+		if o.redactedKeys == nil {
+			o.redactedKeys = make(map[string]struct{}, len(keys))
+		}
+		for _, k := range keys {
+			o.redactedKeys[k] = struct{}{}
+		}
+	}
+}
+
+// WithRedactionEnabled enforces the keys given to WithRedactedKeys only while
+// enabled returns true, such as in production but not in staging. It is called
+// for every entry, so the toggle can be flipped at runtime, from configuration,
+// without rebuilding the Logger. It must be fast and safe for concurrent use.
+func WithRedactionEnabled(enabled func() bool) Option {
+	return func(o *options) {
+		o.redactionEnabled = enabled
+	}
+}
+
+func (r *root) redact(o *options, fields []zapcore.Field) []zapcore.Field {
+	if len(o.redactedKeys) == 0 || (o.redactionEnabled != nil && !o.redactionEnabled()) {
+		return fields
+	}
+	for i, f := range fields {
+		if _, ok := o.redactedKeys[f.Key]; ok {
+			fields[i] = zap.String(f.Key, redactedValue)
+			r.stats.addRedacted(1)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,45 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithRedactedKeys(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithRedactedKeys("password", "token"))
+
+	ctx, _ := l.With(context.Background(), "token", "<token-value>")
+	l.Infow(ctx, "something goes here", "password", "<password-value>", "key", "value")
+
+	require.Equal(t,
+		`{"level":"info","msg":"something goes here","token":"[REDACTED]","password":"[REDACTED]","key":"value"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestWithRedactionEnabled(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	var enabled int32
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(),
+		WithRedactedKeys("password"),
+		WithRedactionEnabled(func() bool { return atomic.LoadInt32(&enabled) == 1 }),
+	)
+
+	ctx, _ := l.With(context.Background())
+	l.Infow(ctx, "disabled", "password", "<password-value>")
+	atomic.StoreInt32(&enabled, 1)
+	l.Infow(ctx, "enabled", "password", "<password-value>")
+
+	require.Equal(t,
+		`{"level":"info","msg":"disabled","password":"<password-value>"}`+"\n"+
+			`{"level":"info","msg":"enabled","password":"[REDACTED]"}`+"\n",
+		buf.String(),
+	)
+	require.Equal(t, uint64(1), l.Stats().Redacted)
+}
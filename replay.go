@@ -0,0 +1,29 @@
+package loggy
+
+import (
+	"context"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// LogEntry is a captured entry, with the fields it was written with. It has the
+// layout of the entries captured by zap's zaptest/observer package, so they can
+// be converted with LogEntry(observed).
+type LogEntry struct {
+	zapcore.Entry
+	Context []zapcore.Field
+}
+
+// Replay writes entries again through the logger carried by ctx, or to if ctx
+// does not carry one, each with its original time, level, logger name, message,
+// stack and fields, on top of the fields of that logger. It can feed real traffic
+// captured from one sink to another configuration. Entries at PanicLevel and
+// above are written without panicking or exiting.
+func Replay(ctx context.Context, entries []LogEntry, to Logger) {
+	c := to.sugar(ctx).Desugar().Core()
+	for _, e := range entries {
+		if ce := c.Check(e.Entry, nil); ce != nil {
+			ce.Write(e.Context...)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestReplay(t *testing.T) {
+	observed, logs := observer.New(zapcore.DebugLevel)
+	source := New(zap.New(observed).Sugar())
+
+	ctx, _ := source.With(context.Background(), "request_id", "<request-id-value>")
+	ctx, _ = source.Named(ctx, "app")
+	source.Infow(ctx, "something goes here", "key", "value")
+	source.Errorw(ctx, "request failed", "password", "<password-value>")
+
+	var entries []LogEntry
+	for _, e := range logs.All() {
+		entries = append(entries, LogEntry(e))
+	}
+
+	buf := bytes.NewBuffer([]byte{})
+	target := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithRedactedKeys("password"))
+	Replay(context.Background(), entries, target)
+
+	require.Equal(t,
+		`{"level":"info","logger":"app","msg":"something goes here","request_id":"<request-id-value>","key":"value"}`+"\n"+
+			`{"level":"error","logger":"app","msg":"request failed","request_id":"<request-id-value>","password":"[REDACTED]"}`+"\n",
+		buf.String(),
+	)
+}
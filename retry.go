@@ -0,0 +1,49 @@
+package loggy
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+// FinalAttempt marks err as the error returned by the last attempt of a retried
+// operation. The function returned by RetryLogger logs such an error at ErrorLevel.
+// FinalAttempt returns nil if err is nil.
+func FinalAttempt(err error) error {
+	if err == nil {
+		return nil
+	}
+	return finalAttemptError{err: err}
+}
+
+type finalAttemptError struct {
+	err error
+}
+
+func (e finalAttemptError) Error() string {
+	return e.err.Error()
+}
+
+func (e finalAttemptError) Unwrap() error {
+	return e.err
+}
+
+// RetryLogger returns a function to call after every attempt of operation.
+// Each call logs the operation, the attempt number and the error, if any:
+// a failed attempt is logged at WarnLevel, a failure marked with FinalAttempt
+// is logged at ErrorLevel, and a nil error is logged at InfoLevel as a success.
+func (l Logger) RetryLogger(ctx context.Context, operation string) func(attempt int, err error) {
+	s := l.sugar(ctx)
+	return func(attempt int, err error) {
+		var final finalAttemptError
+		switch {
+		case err == nil:
+			s.Infow("operation succeeded", "operation", operation, "attempt", attempt)
+		case errors.As(err, &final):
+			s.Errorw("operation failed, giving up", "operation", operation, "attempt", attempt, zap.Error(final.err))
+		default:
+			s.Warnw("operation attempt failed", "operation", operation, "attempt", attempt, zap.Error(err))
+		}
+	}
+}
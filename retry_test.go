@@ -0,0 +1,59 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_RetryLogger(t *testing.T) {
+	tests := map[string]struct {
+		errs []error
+	}{
+		"Should escalate to error level when giving up": {
+			errs: []error{
+				errors.New("connection refused"),
+				errors.New("connection refused"),
+				FinalAttempt(errors.New("connection refused")),
+			},
+		},
+		"Should log success at info level after retries": {
+			errs: []error{
+				errors.New("connection refused"),
+				nil,
+			},
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+
+			zapLogger := newZapTestLogger(t, zapcore.AddSync(buf))
+			l := New(zapLogger.Sugar())
+
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+
+			logAttempt := l.RetryLogger(ctx, "fetch")
+			for i, err := range tc.errs {
+				logAttempt(i+1, err)
+			}
+
+			if *updateGolden {
+				t.Log("Updating golden file:", goldenFilename(t))
+				require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+				require.NoError(t, os.WriteFile(goldenFilename(t), buf.Bytes(), 0644))
+			}
+
+			golden, err := os.ReadFile(goldenFilename(t))
+			require.NoError(t, err)
+			require.Equal(t, buf.Bytes(), golden)
+		})
+	}
+}
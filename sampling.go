@@ -0,0 +1,128 @@
+package loggy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WithSampling caps the entries written with the same level and message. Within
+// each tick, the first entries are written, then only every thereafter-th one;
+// a thereafter of zero drops the rest. A tick of zero or less never starts over.
+// Like zap's sampler, messages are counted in a fixed number of buckets, so
+// messages with dynamic text cannot grow the counts without bound; two messages
+// sharing a bucket are counted together.
+// Sampling state is shared by every logger derived from the same Logger, unless
+// it is reset for a context with ResetSampling.
+func WithSampling(tick time.Duration, first, thereafter int) Option {
+	return func(o *options) {
+		o.sampling = &samplingConfig{tick: tick, first: first, thereafter: thereafter}
+	}
+}
+
+// WithSamplingHook calls hook with every entry considered by sampling, and
+// whether it was kept, to observe how much sampling drops. It is called
+// synchronously at the log site, so it must be fast and safe for concurrent use.
+// Dropped entries are also counted in Stats().SampledDropped.
+func WithSamplingHook(hook func(ent zapcore.Entry, sampled bool)) Option {
+	return func(o *options) {
+		o.samplingHook = hook
+	}
+}
+
+// ResetSampling returns a copy of ctx whose logger samples entries independently
+// of every other context. Middleware can call it at the start of each request so
+// that every request gets its own allowance.
+func (l Logger) ResetSampling(ctx context.Context) context.Context {
+	l = l.extractLogger(ctx)
+	newLogger := Logger{s: withCore(l.s, func(c *core) { c.sampler = &sampler{} }), root: l.root}
+	return newLogger.inject(ctx)
+}
+
+type samplingConfig struct {
+	tick       time.Duration
+	first      int
+	thereafter int
+}
+
+// sample reports whether ent is kept by sampling.
+func (c *core) sample(ent zapcore.Entry) bool {
+	o := c.root.options()
+	if o.sampling == nil {
+		return true
+	}
+	s := c.sampler
+	if s == nil {
+		s = &c.root.sampler
+	}
+	sampled := s.sample(ent, o.sampling)
+	if !sampled {
+		c.root.stats.addSampledDropped(1)
+	}
+	if o.samplingHook != nil {
+		o.samplingHook(ent, sampled)
+	}
+	return sampled
+}
+
+// samplingBuckets is the number of buckets the messages of each level are counted in.
+const samplingBuckets = 4096
+
+// sampler counts entries by level and message bucket. Counts are only allocated
+// for the buckets in use, so samplers created per request stay small.
+type sampler struct {
+	mu     sync.Mutex
+	counts map[samplingKey]*samplingCount
+}
+
+type samplingKey struct {
+	level  zapcore.Level
+	bucket uint32
+}
+
+type samplingCount struct {
+	// start is the time of the first entry of the tick.
+	start time.Time
+	n     int
+}
+
+func (s *sampler) sample(ent zapcore.Entry, cfg *samplingConfig) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := samplingKey{level: ent.Level, bucket: samplingBucket(ent.Message)}
+	count, ok := s.counts[k]
+	if !ok {
+		if s.counts == nil {
+			s.counts = map[samplingKey]*samplingCount{}
+		}
+		count = &samplingCount{start: ent.Time}
+		s.counts[k] = count
+	}
+	if cfg.tick > 0 && ent.Time.Sub(count.start) >= cfg.tick {
+		*count = samplingCount{start: ent.Time}
+	}
+
+	count.n++
+	if count.n <= cfg.first {
+		return true
+	}
+	return cfg.thereafter > 0 && (count.n-cfg.first)%cfg.thereafter == 0
+}
+
+// samplingBucket returns the bucket msg is counted in, from its 32-bit FNV-1a
+// hash, computed inline as zap does to avoid allocating.
+func samplingBucket(msg string) uint32 {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	h := uint32(offset32)
+	for i := 0; i < len(msg); i++ {
+		h ^= uint32(msg[i])
+		h *= prime32
+	}
+	return h % samplingBuckets
+}
@@ -0,0 +1,96 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithSampling(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	clock := &manualClock{now: time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)}
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf), zap.WithClock(clock)).Sugar(), WithSampling(time.Second, 2, 3))
+
+	ctx, _ := l.With(context.Background())
+	for i := 0; i < 8; i++ {
+		l.Infow(ctx, "sampled", "i", i)
+	}
+	l.Warnw(ctx, "sampled", "i", 0)
+
+	clock.now = clock.now.Add(time.Second)
+	l.Infow(ctx, "sampled", "i", 8)
+
+	require.Equal(t,
+		`{"level":"info","msg":"sampled","i":0}`+"\n"+
+			`{"level":"info","msg":"sampled","i":1}`+"\n"+
+			`{"level":"info","msg":"sampled","i":4}`+"\n"+
+			`{"level":"info","msg":"sampled","i":7}`+"\n"+
+			`{"level":"warn","msg":"sampled","i":0}`+"\n"+
+			`{"level":"info","msg":"sampled","i":8}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestLogger_ResetSampling(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithSampling(0, 2, 0))
+
+	// Use up the allowance shared by contexts that were not reset.
+	ctx, _ := l.With(context.Background())
+	for i := 0; i < 3; i++ {
+		l.Info(ctx, "handled")
+	}
+	buf.Reset()
+
+	for _, requestID := range []string{"<first-request>", "<second-request>"} {
+		ctx, _ := l.With(context.Background(), "request_id", requestID)
+		ctx = l.ResetSampling(ctx)
+		for i := 0; i < 3; i++ {
+			l.Info(ctx, "handled")
+		}
+	}
+
+	require.Equal(t,
+		`{"level":"info","msg":"handled","request_id":"<first-request>"}`+"\n"+
+			`{"level":"info","msg":"handled","request_id":"<first-request>"}`+"\n"+
+			`{"level":"info","msg":"handled","request_id":"<second-request>"}`+"\n"+
+			`{"level":"info","msg":"handled","request_id":"<second-request>"}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestWithSamplingHook(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	var decisions []bool
+	hook := func(ent zapcore.Entry, sampled bool) {
+		require.Equal(t, "sampled", ent.Message)
+		decisions = append(decisions, sampled)
+	}
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithSampling(0, 1, 0), WithSamplingHook(hook))
+
+	ctx, _ := l.With(context.Background())
+	for i := 0; i < 3; i++ {
+		l.Info(ctx, "sampled")
+	}
+
+	require.Equal(t, []bool{true, false, false}, decisions)
+	require.Equal(t, uint64(2), l.Stats().SampledDropped)
+	require.Equal(t, `{"level":"info","msg":"sampled"}`+"\n", buf.String())
+}
+
+func TestWithSampling_BoundedCounts(t *testing.T) {
+	l := New(newZapTestLogger(t, zapcore.AddSync(&bytes.Buffer{})).Sugar(), WithSampling(0, 1, 0))
+
+	for i := 0; i < 3*samplingBuckets; i++ {
+		l.Infof(context.Background(), "request %d handled", i)
+	}
+
+	l.root.sampler.mu.Lock()
+	defer l.root.sampler.mu.Unlock()
+	require.LessOrEqual(t, len(l.root.sampler.counts), samplingBuckets)
+}
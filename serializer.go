@@ -0,0 +1,77 @@
+package loggy
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// serializers maps types to the functions registered with RegisterFieldSerializer.
+// The map is replaced on every registration, so it can be read without locking.
+var (
+	serializers   atomic.Value // map[reflect.Type]func(interface{}) interface{}
+	serializersMu sync.Mutex
+)
+
+// RegisterFieldSerializer makes every Logger encode the field values of the
+// concrete type of sample as fn returns them, whether the fields were added with
+// With or passed at the log site. For example, a money.Amount can be logged as
+// "12.34 USD". Registering a type again replaces its serializer. It is meant to
+// be called during initialization. The returned function unregisters fn,
+// restoring the serializer it replaced, if any, so tests can undo a
+// registration with t.Cleanup. It panics if sample is nil, as a nil interface
+// has no concrete type to register fn for.
+func RegisterFieldSerializer(sample interface{}, fn func(interface{}) interface{}) (unregister func()) {
+	if sample == nil {
+		panic("loggy: RegisterFieldSerializer called with a nil sample")
+	}
+	t := reflect.TypeOf(sample)
+	prev, hadPrev := setSerializer(t, fn, true)
+	return func() {
+		setSerializer(t, prev, hadPrev)
+	}
+}
+
+// setSerializer sets the serializer of t to fn, or removes it when set is false,
+// and returns the serializer it replaced, if any.
+func setSerializer(t reflect.Type, fn func(interface{}) interface{}, set bool) (func(interface{}) interface{}, bool) {
+	serializersMu.Lock()
+	defer serializersMu.Unlock()
+
+	old, _ := serializers.Load().(map[reflect.Type]func(interface{}) interface{})
+	prev, hadPrev := old[t]
+	m := make(map[reflect.Type]func(interface{}) interface{}, len(old)+1)
+	for k, f := range old {
+		m[k] = f
+	}
+	if set {
+		m[t] = fn
+	} else {
+		delete(m, t)
+	}
+	serializers.Store(m)
+	return prev, hadPrev
+}
+
+// serialize replaces the values of fields with a registered serializer with what it returns.
+func serialize(fields []zapcore.Field) []zapcore.Field {
+	m, _ := serializers.Load().(map[reflect.Type]func(interface{}) interface{})
+	if len(m) == 0 {
+		return fields
+	}
+	for i, f := range fields {
+		switch f.Type {
+		case zapcore.ReflectType, zapcore.StringerType, zapcore.ErrorType,
+			zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType:
+		default:
+			continue
+		}
+		if fn, ok := m[reflect.TypeOf(f.Interface)]; ok {
+			fields[i] = zap.Any(f.Key, fn(f.Interface))
+		}
+	}
+	return fields
+}
@@ -0,0 +1,72 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+type serializerAmount struct {
+	Cents    int64
+	Currency string
+}
+
+func TestRegisterFieldSerializer(t *testing.T) {
+	t.Cleanup(RegisterFieldSerializer(serializerAmount{}, func(v interface{}) interface{} {
+		a := v.(serializerAmount)
+		return fmt.Sprintf("%d.%02d %s", a.Cents/100, a.Cents%100, a.Currency)
+	}))
+
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	ctx, _ := l.With(context.Background(), "total", serializerAmount{Cents: 1234, Currency: "USD"})
+	l.Infow(ctx, "order placed", "tax", serializerAmount{Cents: 99, Currency: "USD"}, "shipping", &serializerAmount{Cents: 500})
+
+	require.Equal(t,
+		`{"level":"info","msg":"order placed","total":"12.34 USD","tax":"0.99 USD","shipping":{"Cents":500,"Currency":""}}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestRegisterFieldSerializer_Unregister(t *testing.T) {
+	unregisterFirst := RegisterFieldSerializer(serializerAmount{}, func(v interface{}) interface{} {
+		return "first"
+	})
+	t.Cleanup(unregisterFirst)
+	unregisterSecond := RegisterFieldSerializer(serializerAmount{}, func(v interface{}) interface{} {
+		return "second"
+	})
+
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	l.Infow(context.Background(), "order placed", "total", serializerAmount{Cents: 1234, Currency: "USD"})
+	unregisterSecond()
+	l.Infow(context.Background(), "order placed", "total", serializerAmount{Cents: 1234, Currency: "USD"})
+	unregisterFirst()
+	l.Infow(context.Background(), "order placed", "total", serializerAmount{Cents: 1234, Currency: "USD"})
+
+	require.Equal(t,
+		`{"level":"info","msg":"order placed","total":"second"}`+"\n"+
+			`{"level":"info","msg":"order placed","total":"first"}`+"\n"+
+			`{"level":"info","msg":"order placed","total":{"Cents":1234,"Currency":"USD"}}`+"\n",
+		buf.String(),
+	)
+}
+
+func TestRegisterFieldSerializer_NilSample(t *testing.T) {
+	require.PanicsWithValue(t, "loggy: RegisterFieldSerializer called with a nil sample", func() {
+		RegisterFieldSerializer(nil, func(v interface{}) interface{} { return "serialized" })
+	})
+
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+	l.Infow(context.Background(), "something goes here", "value", nil)
+
+	require.Equal(t, `{"level":"info","msg":"something goes here","value":null}`+"\n", buf.String())
+}
@@ -0,0 +1,95 @@
+package loggy
+
+import (
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Sink is a destination of a Logger created by NewMultiSink.
+type Sink struct {
+	// WriteSyncer is where the entries are written.
+	WriteSyncer zapcore.WriteSyncer
+	// Encoder encodes the entries written to the sink.
+	Encoder zapcore.Encoder
+	// Level filters the entries written to the sink. A zapcore.Level enables
+	// itself and every level above it. A nil Level enables InfoLevel and above.
+	Level zapcore.LevelEnabler
+}
+
+// NewMultiSink creates a Logger configured with opts that writes every entry to
+// each of sinks whose level enables it, each with its own encoder. For example,
+// a JSON sink on stdout can take every entry while a console sink on stderr only
+// takes errors.
+func NewMultiSink(sinks []Sink, opts ...Option) Logger {
+	tee := make(teeCore, len(sinks))
+	for i, sink := range sinks {
+		level := sink.Level
+		if level == nil {
+			level = zapcore.InfoLevel
+		}
+		tee[i] = zapcore.NewCore(sink.Encoder, sink.WriteSyncer, level)
+	}
+	// Skip the frame of the Logger method so the caller is the log site.
+	return New(zap.New(tee, zap.AddCaller(), zap.AddCallerSkip(1)).Sugar(), opts...)
+}
+
+// AddSink returns a Logger that writes to ws, encoded with enc, every entry at
+// minLevel and above, in addition to everything l already writes to.
+// l itself is unaffected. Like any Logger, the returned one is only used when
+// the context passed at the log site does not carry a logger, so inject it with
+// With to use it further down the stack.
+func (l Logger) AddSink(ws zapcore.WriteSyncer, enc zapcore.Encoder, minLevel zapcore.Level) Logger {
+	sink := zapcore.NewCore(enc, ws, minLevel)
+	s := withCore(l.s, func(c *core) {
+		c.base = teeCore{c.base, sink}
+	})
+	return Logger{s: s, root: l.root}
+}
+
+// teeCore writes an entry to each of its cores that has the entry's level enabled.
+// Unlike zapcore.NewTee it checks the level on Write, since loggy's core only
+// calls Write on the cores it wraps.
+type teeCore []zapcore.Core
+
+func (t teeCore) Enabled(lvl zapcore.Level) bool {
+	for _, c := range t {
+		if c.Enabled(lvl) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t teeCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := make(teeCore, len(t))
+	for i, c := range t {
+		clone[i] = c.With(fields)
+	}
+	return clone
+}
+
+func (t teeCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	for _, c := range t {
+		ce = c.Check(ent, ce)
+	}
+	return ce
+}
+
+func (t teeCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	var err error
+	for _, c := range t {
+		if c.Enabled(ent.Level) {
+			err = multierr.Append(err, c.Write(ent, fields))
+		}
+	}
+	return err
+}
+
+func (t teeCore) Sync() error {
+	var err error
+	for _, c := range t {
+		err = multierr.Append(err, c.Sync())
+	}
+	return err
+}
@@ -0,0 +1,79 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_AddSink(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	sinkBuf := bytes.NewBuffer([]byte{})
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: zapcore.LowercaseLevelEncoder})
+	withSink := l.AddSink(zapcore.AddSync(sinkBuf), enc, zapcore.WarnLevel)
+
+	ctx, _ := withSink.With(context.Background(), "request_id", "<request-id-value>")
+	withSink.Info(ctx, "info")
+	withSink.Warn(ctx, "warn")
+	l.Warn(context.Background(), "original")
+
+	require.Equal(t,
+		`{"level":"info","msg":"info","request_id":"<request-id-value>"}`+"\n"+
+			`{"level":"warn","msg":"warn","request_id":"<request-id-value>"}`+"\n"+
+			`{"level":"warn","msg":"original"}`+"\n",
+		buf.String(),
+	)
+	require.Equal(t,
+		`{"level":"warn","msg":"warn","request_id":"<request-id-value>"}`+"\n",
+		sinkBuf.String(),
+	)
+}
+
+func TestNewMultiSink(t *testing.T) {
+	stdout := bytes.NewBuffer([]byte{})
+	stderr := bytes.NewBuffer([]byte{})
+	cfg := zapcore.EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: zapcore.LowercaseLevelEncoder}
+
+	l := NewMultiSink([]Sink{
+		{WriteSyncer: zapcore.AddSync(stdout), Encoder: zapcore.NewJSONEncoder(cfg), Level: zapcore.DebugLevel},
+		{WriteSyncer: zapcore.AddSync(stderr), Encoder: zapcore.NewConsoleEncoder(cfg), Level: zapcore.ErrorLevel},
+	})
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	l.Debug(ctx, "debug")
+	l.Errorw(ctx, "error", "key", "value")
+
+	require.Equal(t,
+		`{"level":"debug","msg":"debug","request_id":"<request-id-value>"}`+"\n"+
+			`{"level":"error","msg":"error","request_id":"<request-id-value>","key":"value"}`+"\n",
+		stdout.String(),
+	)
+	require.Equal(t,
+		"error\terror\t"+`{"request_id": "<request-id-value>", "key": "value"}`+"\n",
+		stderr.String(),
+	)
+}
+
+func TestNewMultiSink_Options(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	cfg := zapcore.EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: zapcore.LowercaseLevelEncoder}
+
+	l := NewMultiSink(
+		[]Sink{{WriteSyncer: zapcore.AddSync(buf), Encoder: zapcore.NewJSONEncoder(cfg)}},
+		WithRedactedKeys("password"),
+	)
+
+	ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+	l.Debug(ctx, "debug")
+	l.Infow(ctx, "info", "password", "<password-value>")
+
+	require.Equal(t,
+		`{"level":"info","msg":"info","request_id":"<request-id-value>","password":"[REDACTED]"}`+"\n",
+		buf.String(),
+	)
+}
@@ -0,0 +1,117 @@
+//go:build go1.21
+// +build go1.21
+
+package loggy
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewFromSlog creates a Logger that writes its entries to h, so loggy can be
+// adopted by code already built around log/slog. Fields are converted to slog
+// attributes, and fields following a namespace, added with Logger.Namespace, are
+// grouped under it as with slog.Group. The logger name is written in the logger
+// attribute. Levels above ErrorLevel map to levels above slog.LevelError. The
+// context passed at the log site is passed on to h. It is only available from
+// Go 1.21.
+func NewFromSlog(h slog.Handler, opts ...Option) Logger {
+	return New(zap.New(&slogCore{handler: h}).Sugar(), opts...)
+}
+
+// slogCore is a zapcore.Core writing to a slog.Handler.
+type slogCore struct {
+	handler slog.Handler
+}
+
+func (c *slogCore) Enabled(lvl zapcore.Level) bool {
+	return c.handler.Enabled(context.Background(), slogLevel(lvl))
+}
+
+func (c *slogCore) With(fields []zapcore.Field) zapcore.Core {
+	h := c.handler
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, f := range fields {
+		if f.Type == zapcore.NamespaceType {
+			h = h.WithAttrs(attrs).WithGroup(f.Key)
+			attrs = attrs[:0]
+			continue
+		}
+		attrs = appendAttrs(attrs, f)
+	}
+	return &slogCore{handler: h.WithAttrs(attrs)}
+}
+
+func (c *slogCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *slogCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.WriteContext(context.Background(), ent, fields)
+}
+
+// WriteContext writes ent to the handler with ctx, the context passed at the log
+// site, so handlers reading values from it, such as trace IDs, find them.
+func (c *slogCore) WriteContext(ctx context.Context, ent zapcore.Entry, fields []zapcore.Field) error {
+	r := slog.NewRecord(ent.Time, slogLevel(ent.Level), ent.Message, 0)
+	if ent.LoggerName != "" {
+		r.AddAttrs(slog.String("logger", ent.LoggerName))
+	}
+	r.AddAttrs(groupAttrs(fields)...)
+	return c.handler.Handle(ctx, r)
+}
+
+func (c *slogCore) Sync() error {
+	return nil
+}
+
+// slogLevel maps lvl to the slog level with the same name.
+func slogLevel(lvl zapcore.Level) slog.Level {
+	return slog.Level(slogLevels.toBackend(lvl))
+}
+
+// groupAttrs converts fields to attributes, nesting the fields that follow a
+// namespace in a group named after it.
+func groupAttrs(fields []zapcore.Field) []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for i, f := range fields {
+		if f.Type == zapcore.NamespaceType {
+			return append(attrs, slog.Attr{Key: f.Key, Value: slog.GroupValue(groupAttrs(fields[i+1:])...)})
+		}
+		attrs = appendAttrs(attrs, f)
+	}
+	return attrs
+}
+
+// appendAttrs appends the attributes encoded by f, which are usually a single
+// one, although an error can also encode its verbose form.
+func appendAttrs(attrs []slog.Attr, f zapcore.Field) []slog.Attr {
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	return append(attrs, mapAttrs(enc.Fields)...)
+}
+
+func mapAttrs(m map[string]interface{}) []slog.Attr {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	attrs := make([]slog.Attr, 0, len(keys))
+	for _, k := range keys {
+		if nested, ok := m[k].(map[string]interface{}); ok {
+			attrs = append(attrs, slog.Attr{Key: k, Value: slog.GroupValue(mapAttrs(nested)...)})
+			continue
+		}
+		attrs = append(attrs, slog.Any(k, m[k]))
+	}
+	return attrs
+}
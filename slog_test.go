@@ -0,0 +1,115 @@
+//go:build go1.21
+// +build go1.21
+
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func newSlogTestHandler(buf *bytes.Buffer) slog.Handler {
+	return slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+}
+
+func TestNewFromSlog(t *testing.T) {
+	tests := map[string]struct {
+		log      func(l Logger)
+		expected string
+	}{
+		"Should write fields as attributes": {
+			log: func(l Logger) {
+				ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+				l.Infow(ctx, "something goes here", "key", "value", zap.Error(errors.New("boom")))
+			},
+			expected: `{"level":"INFO","msg":"something goes here","request_id":"<request-id-value>","key":"value","error":"boom"}`,
+		},
+		"Should group fields following a namespace": {
+			log: func(l Logger) {
+				ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+				ctx, _ = l.Namespace(ctx, "http")
+				l.Infow(ctx, "req", "status", 200)
+			},
+			expected: `{"level":"INFO","msg":"req","request_id":"<request-id-value>","http":{"status":200}}`,
+		},
+		"Should preserve nested objects": {
+			log: func(l Logger) {
+				ctx, _ := l.Named(context.Background(), "app")
+				l.Warnw(ctx, "something goes here", zap.Object("user", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+					enc.AddString("name", "gopher")
+					enc.AddInt("id", 1)
+					return nil
+				})))
+			},
+			expected: `{"level":"WARN","msg":"something goes here","logger":"app","user":{"id":1,"name":"gopher"}}`,
+		},
+		"Should map levels above error": {
+			log: func(l Logger) {
+				l.DPanicw(context.Background(), "something goes here")
+			},
+			expected: `{"level":"ERROR+4","msg":"something goes here"}`,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			tc.log(NewFromSlog(newSlogTestHandler(buf)))
+			require.Equal(t, tc.expected+"\n", buf.String())
+		})
+	}
+}
+
+// traceHandler adds the trace ID carried by the context of every record, like
+// the handlers of tracing libraries do.
+type traceHandler struct {
+	slog.Handler
+}
+
+type traceIDCtxKey struct{}
+
+func (h traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id, ok := ctx.Value(traceIDCtxKey{}).(string); ok {
+		r.AddAttrs(slog.String("trace_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return traceHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h traceHandler) WithGroup(name string) slog.Handler {
+	return traceHandler{h.Handler.WithGroup(name)}
+}
+
+func TestNewFromSlog_PassesContext(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := NewFromSlog(traceHandler{newSlogTestHandler(buf)})
+
+	ctx := context.WithValue(context.Background(), traceIDCtxKey{}, "<trace-id-value>")
+	ctx, _ = l.With(ctx, "request_id", "<request-id-value>")
+	l.Info(ctx, "something goes here")
+	l.Info(context.Background(), "no trace")
+
+	require.Equal(t,
+		`{"level":"INFO","msg":"something goes here","request_id":"<request-id-value>","trace_id":"<trace-id-value>"}`+"\n"+
+			`{"level":"INFO","msg":"no trace"}`+"\n",
+		buf.String(),
+	)
+}
@@ -0,0 +1,58 @@
+package loggy
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// truncatedStackMarker ends the stacks trimmed by WithStacktraceMaxFrames.
+const truncatedStackMarker = "...truncated"
+
+// WithStacktraceMaxFrames trims the stacks captured for entries to their
+// innermost n frames, followed by a "...truncated" line. It applies to the
+// stack zap captures with zap.AddStacktrace and to the stack field logged by
+// Recover and RecoveryMiddleware.
+func WithStacktraceMaxFrames(n int) Option {
+	return func(o *options) {
+		o.stackMaxFrames = n
+	}
+}
+
+// trimStacks trims the stack of ent and the stack field as WithStacktraceMaxFrames configures.
+func (r *root) trimStacks(o *options, ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+	if o.stackMaxFrames <= 0 {
+		return ent, fields
+	}
+	if s, ok := trimStack(ent.Stack, o.stackMaxFrames); ok {
+		ent.Stack = s
+		r.stats.addTruncated(1)
+	}
+	for i, f := range fields {
+		if f.Key != "stack" || f.Type != zapcore.ByteStringType {
+			continue
+		}
+		if s, ok := trimStack(string(f.Interface.([]byte)), o.stackMaxFrames); ok {
+			fields[i] = zap.String(f.Key, s)
+			r.stats.addTruncated(1)
+		}
+	}
+	return ent, fields
+}
+
+// trimStack keeps the first n frames of stack, in the format of zap and of
+// runtime/debug.Stack, where a frame spans a function line and a file line.
+// It reports whether stack had more frames.
+func trimStack(stack string, n int) (string, bool) {
+	lines := strings.Split(strings.TrimSuffix(stack, "\n"), "\n")
+	keep := 2 * n
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "goroutine ") {
+		// Keep the header of runtime/debug.Stack.
+		keep++
+	}
+	if len(lines) <= keep {
+		return stack, false
+	}
+	return strings.Join(append(lines[:keep:keep], truncatedStackMarker), "\n"), true
+}
@@ -0,0 +1,89 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithStacktraceMaxFrames(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	encoderCfg := zapcore.EncoderConfig{
+		MessageKey:    "msg",
+		LevelKey:      "level",
+		StacktraceKey: "stacktrace",
+		EncodeLevel:   zapcore.LowercaseLevelEncoder,
+	}
+	zapLogger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(encoderCfg), zapcore.AddSync(buf), zap.DebugLevel), zap.AddStacktrace(zap.ErrorLevel))
+	l := New(zapLogger.Sugar(), WithStacktraceMaxFrames(2))
+
+	ctx, _ := l.With(context.Background())
+	l.Error(ctx, "something goes here")
+	l.Info(ctx, "no stack")
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 2)
+	stack := strings.Split(lines[0]["stacktrace"].(string), "\n")
+	require.Len(t, stack, 5)
+	require.Equal(t, truncatedStackMarker, stack[4])
+	require.NotContains(t, lines[1], "stacktrace")
+	require.Equal(t, Stats{Truncated: 1}, l.Stats())
+}
+
+func TestWithStacktraceMaxFrames_Recover(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithStacktraceMaxFrames(1))
+
+	ctx, _ := l.With(context.Background())
+	func() {
+		defer l.Recover(ctx)
+		panic("boom")
+	}()
+
+	lines := decodeLines(t, buf)
+	require.Len(t, lines, 1)
+	stack := strings.Split(lines[0]["stack"].(string), "\n")
+	require.Len(t, stack, 4)
+	require.True(t, strings.HasPrefix(stack[0], "goroutine "))
+	require.Equal(t, truncatedStackMarker, stack[3])
+}
+
+func TestTrimStack(t *testing.T) {
+	tests := map[string]struct {
+		stack   string
+		n       int
+		want    string
+		trimmed bool
+	}{
+		"Should keep a short stack": {
+			stack: "main.a\n\ta.go:1\n",
+			n:     1,
+			want:  "main.a\n\ta.go:1\n",
+		},
+		"Should trim a long stack": {
+			stack:   "main.a\n\ta.go:1\nmain.b\n\tb.go:2",
+			n:       1,
+			want:    "main.a\n\ta.go:1\n...truncated",
+			trimmed: true,
+		},
+		"Should keep the goroutine header": {
+			stack:   "goroutine 1 [running]:\nmain.a()\n\ta.go:1\nmain.b()\n\tb.go:2\n",
+			n:       1,
+			want:    "goroutine 1 [running]:\nmain.a()\n\ta.go:1\n...truncated",
+			trimmed: true,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, trimmed := trimStack(tc.stack, tc.n)
+			require.Equal(t, tc.want, got)
+			require.Equal(t, tc.trimmed, trimmed)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package loggy
+
+import (
+	"sync/atomic"
+)
+
+// Stats counts the fields and entries loggy altered or removed before encoding.
+// A steadily growing count usually points at misconfiguration or at
+// call sites logging data they should not.
+type Stats struct {
+	// Redacted is the number of field values replaced by redaction or masked.
+	Redacted uint64
+	// Truncated is the number of field values shortened to fit a limit.
+	Truncated uint64
+	// Dropped is the number of fields removed entirely.
+	Dropped uint64
+	// SampledDropped is the number of entries dropped by sampling.
+	SampledDropped uint64
+	// Messages is the number of entries written with each level and message,
+	// counted when WithMessageCounter is set.
+	Messages map[MessageKey]uint64
+}
+
+// Stats returns a snapshot of the counters shared by l and every logger derived from it.
+func (l Logger) Stats() Stats {
+	return l.root.stats.snapshot()
+}
+
+// stats holds the live counters. The counters are only touched when a field is
+// altered, so the common path pays nothing for them.
+type stats struct {
+	redacted  uint64
+	truncated uint64
+	dropped   uint64
+	// sampledDropped counts entries rather than fields.
+	sampledDropped uint64
+	messages       messageCounts
+}
+
+func (s *stats) addRedacted(n uint64) {
+	atomic.AddUint64(&s.redacted, n)
+}
+
+func (s *stats) addTruncated(n uint64) {
+	atomic.AddUint64(&s.truncated, n)
+}
+
+func (s *stats) addDropped(n uint64) {
+	atomic.AddUint64(&s.dropped, n)
+}
+
+func (s *stats) addSampledDropped(n uint64) {
+	atomic.AddUint64(&s.sampledDropped, n)
+}
+
+func (s *stats) snapshot() Stats {
+	return Stats{
+		Redacted:       atomic.LoadUint64(&s.redacted),
+		Truncated:      atomic.LoadUint64(&s.truncated),
+		Dropped:        atomic.LoadUint64(&s.dropped),
+		SampledDropped: atomic.LoadUint64(&s.sampledDropped),
+		Messages:       s.messages.snapshot(),
+	}
+}
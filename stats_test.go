@@ -0,0 +1,23 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_Stats(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithRedactedKeys("password"))
+
+	l.Infow(context.Background(), "something goes here", "key", "value")
+	require.Equal(t, Stats{}, l.Stats())
+
+	ctx, child := l.With(context.Background(), "request_id", "<request-id-value>")
+	child.Infow(ctx, "something goes here", "password", "<password-value>")
+	require.Equal(t, Stats{Redacted: 1}, l.Stats())
+	require.Equal(t, l.Stats(), child.Stats())
+}
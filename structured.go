@@ -0,0 +1,105 @@
+package loggy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// WithStructuredSingleArg logs a struct or map passed as the only argument to
+// Debug, Info, Warn, Error, DPanic, Panic or Fatal as fields, instead of
+// formatting it into the message with fmt.Sprint. Struct fields are keyed by
+// their json tag, or by their name when they have none; unexported fields and
+// fields tagged "-" are skipped. Pointers are followed, and a nil pointer is
+// logged as-is. Only maps with string keys are expanded. Values that describe
+// themselves, by implementing error, fmt.Stringer, json.Marshaler or
+// encoding.TextMarshaler, such as time.Time, are formatted as usual.
+func WithStructuredSingleArg() Option {
+	return func(o *options) {
+		o.structuredSingleArg = true
+	}
+}
+
+// structuredArgs returns the keys and values to log in place of args, if the
+// logger carried by ctx is configured with WithStructuredSingleArg and args
+// is a single struct or map.
+func (l Logger) structuredArgs(ctx context.Context, args []interface{}) ([]interface{}, bool) {
+	if len(args) != 1 || !l.extractLogger(ctx).root.options().structuredSingleArg {
+		return nil, false
+	}
+	return structuredFields(args[0])
+}
+
+func structuredFields(arg interface{}) ([]interface{}, bool) {
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if v.IsValid() && selfDescribing(v.Type()) {
+		return nil, false
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		kv := make([]interface{}, 0, 2*t.NumField())
+		for i := 0; i < t.NumField(); i++ {
+			if key, ok := structFieldKey(t.Field(i)); ok {
+				kv = append(kv, key, v.Field(i).Interface())
+			}
+		}
+		return kv, true
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+		kv := make([]interface{}, 0, 2*v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			kv = append(kv, iter.Key().String(), iter.Value().Interface())
+		}
+		return kv, true
+	}
+	return nil, false
+}
+
+// selfDescribingTypes are the interfaces of values that format themselves, and
+// so are not expanded into fields.
+var selfDescribingTypes = []reflect.Type{
+	reflect.TypeOf((*error)(nil)).Elem(),
+	reflect.TypeOf((*fmt.Stringer)(nil)).Elem(),
+	jsonMarshalerType,
+	textMarshalerType,
+}
+
+// selfDescribing reports whether t, or a pointer to it, implements one of
+// selfDescribingTypes.
+func selfDescribing(t reflect.Type) bool {
+	for _, iface := range selfDescribingTypes {
+		if t.Implements(iface) || reflect.PtrTo(t).Implements(iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// structFieldKey returns the key of a struct field: its json tag, or its name
+// when it has none. It reports false for fields that are not logged, which are
+// unexported fields and fields tagged "-".
+func structFieldKey(f reflect.StructField) (string, bool) {
+	if f.PkgPath != "" {
+		return "", false
+	}
+	switch tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag {
+	case "-":
+		return "", false
+	case "":
+		return f.Name, true
+	default:
+		return tag, true
+	}
+}
@@ -0,0 +1,100 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// structuredError is an error with exported fields.
+type structuredError struct {
+	Code int
+}
+
+func (e structuredError) Error() string {
+	return fmt.Sprintf("failed with code %d", e.Code)
+}
+
+func TestWithStructuredSingleArg(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+	}
+	type user struct {
+		ID      int     `json:"id"`
+		Name    string  `json:"name"`
+		Address address `json:"address"`
+		Secret  string  `json:"-"`
+		note    string
+	}
+
+	var nilUser *user
+	tests := map[string]struct {
+		arg      interface{}
+		expected string
+	}{
+		"struct": {
+			arg:      user{ID: 1, Name: "gopher", Address: address{City: "Toronto"}, Secret: "s", note: "n"},
+			expected: `{"level":"info","msg":"","id":1,"name":"gopher","address":{"city":"Toronto"}}`,
+		},
+		"pointer to struct": {
+			arg:      &user{ID: 1, Name: "gopher"},
+			expected: `{"level":"info","msg":"","id":1,"name":"gopher","address":{"city":""}}`,
+		},
+		"map": {
+			arg:      map[string]int{"count": 3},
+			expected: `{"level":"info","msg":"","count":3}`,
+		},
+		"string": {
+			arg:      "something goes here",
+			expected: `{"level":"info","msg":"something goes here"}`,
+		},
+		"nil pointer": {
+			arg:      nilUser,
+			expected: `{"level":"info","msg":"<nil>"}`,
+		},
+		"nil": {
+			arg:      nil,
+			expected: `{"level":"info","msg":"<nil>"}`,
+		},
+		"time": {
+			arg:      time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+			expected: `{"level":"info","msg":"2021-01-02 03:04:05 +0000 UTC"}`,
+		},
+		"error struct": {
+			arg:      &structuredError{Code: 7},
+			expected: `{"level":"info","msg":"failed with code 7"}`,
+		},
+		"map with non-string keys": {
+			arg:      map[int]string{1: "one"},
+			expected: `{"level":"info","msg":"map[1:one]"}`,
+		},
+	}
+
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithStructuredSingleArg())
+
+			ctx, _ := l.With(context.Background())
+			l.Info(ctx, tc.arg)
+
+			require.Equal(t, tc.expected+"\n", buf.String())
+		})
+	}
+}
+
+func TestLogger_SingleArgWithoutStructuredOption(t *testing.T) {
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar())
+
+	ctx, _ := l.With(context.Background())
+	l.Info(ctx, map[string]int{"count": 3})
+
+	require.Equal(t, `{"level":"info","msg":"map[count:3]"}`+"\n", buf.String())
+}
@@ -0,0 +1,93 @@
+package loggy
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SummaryContext returns a copy of ctx whose logger counts entries by level
+// instead of writing them, and a function that logs a single request summary
+// line through the logger carried by ctx. The summary has a count field per
+// level seen, such as info_count, and the highest level seen in max_level.
+// It is logged at that level, clamped between InfoLevel and ErrorLevel.
+// Entries at ErrorLevel and above, or at the level given to
+// WithSummaryPassthrough and above, are still written immediately, so errors,
+// panics and fatal entries are never folded into the summary. Only the first call
+// of the returned function logs.
+func (l Logger) SummaryContext(ctx context.Context) (context.Context, func()) {
+	parent := l.extractLogger(ctx)
+	summary := &requestSummary{}
+	newLogger := Logger{s: withCore(parent.s, func(c *core) { c.summary = summary }), root: parent.root}
+
+	var once sync.Once
+	finalize := func() {
+		once.Do(func() {
+			level, args := summary.fields()
+			if s, ok := parent.sugarAt(ctx, level); ok {
+				logw(s, level, "request summary", args...)
+			}
+		})
+	}
+	return newLogger.inject(ctx), finalize
+}
+
+// WithSummaryPassthrough makes the loggers created by SummaryContext write the
+// entries at level and above immediately, in addition to counting them, so
+// warnings, for example, are not held back until the end of a request. Entries at
+// ErrorLevel and above are written immediately regardless.
+func WithSummaryPassthrough(level zapcore.Level) Option {
+	return func(o *options) {
+		o.summaryPassthrough = &level
+	}
+}
+
+// requestSummary counts the entries logged during a request.
+type requestSummary struct {
+	mu     sync.Mutex
+	counts [zapcore.FatalLevel - zapcore.DebugLevel + 1]uint64
+	max    zapcore.Level
+	seen   bool
+}
+
+// add counts ent, and reports whether it must be written nonetheless.
+func (s *requestSummary) add(o *options, ent zapcore.Entry) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ent.Level >= zapcore.DebugLevel && ent.Level <= zapcore.FatalLevel {
+		s.counts[ent.Level-zapcore.DebugLevel]++
+	}
+	if !s.seen || ent.Level > s.max {
+		s.max = ent.Level
+	}
+	s.seen = true
+	return ent.Level >= zapcore.ErrorLevel || (o.summaryPassthrough != nil && ent.Level >= *o.summaryPassthrough)
+}
+
+// fields returns the level to log the summary at, and its fields.
+func (s *requestSummary) fields() (zapcore.Level, []interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var args []interface{}
+	for i, n := range s.counts {
+		if n > 0 {
+			lvl := zapcore.DebugLevel + zapcore.Level(i)
+			args = append(args, strings.ToLower(lvl.String())+"_count", n)
+		}
+	}
+
+	level := zapcore.InfoLevel
+	if s.seen {
+		args = append(args, "max_level", s.max.String())
+		if s.max > level {
+			level = s.max
+		}
+		if level > zapcore.ErrorLevel {
+			level = zapcore.ErrorLevel
+		}
+	}
+	return level, args
+}
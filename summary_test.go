@@ -0,0 +1,75 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestLogger_SummaryContext(t *testing.T) {
+	tests := map[string]struct {
+		opts     []Option
+		log      func(ctx context.Context, l Logger)
+		expected string
+	}{
+		"Should summarize the entries in a single line": {
+			log: func(ctx context.Context, l Logger) {
+				l.Debug(ctx, "debug")
+				l.Info(ctx, "info")
+				l.Info(ctx, "info")
+				l.Warn(ctx, "warn")
+			},
+			expected: `{"level":"warn","msg":"request summary","request_id":"<request-id-value>","debug_count":1,"info_count":2,"warn_count":1,"max_level":"warn"}` + "\n",
+		},
+		"Should write entries at the passthrough level immediately": {
+			opts: []Option{WithSummaryPassthrough(zapcore.ErrorLevel)},
+			log: func(ctx context.Context, l Logger) {
+				l.Info(ctx, "info")
+				l.Errorw(ctx, "error", "key", "value")
+			},
+			expected: `{"level":"error","msg":"error","request_id":"<request-id-value>","key":"value"}` + "\n" +
+				`{"level":"error","msg":"request summary","request_id":"<request-id-value>","info_count":1,"error_count":1,"max_level":"error"}` + "\n",
+		},
+		"Should write entries at ErrorLevel and above without passthrough": {
+			log: func(ctx context.Context, l Logger) {
+				l.Info(ctx, "info")
+				l.Error(ctx, "error")
+				l.DPanic(ctx, "dpanic")
+			},
+			expected: `{"level":"error","msg":"error","request_id":"<request-id-value>"}` + "\n" +
+				`{"level":"dpanic","msg":"dpanic","request_id":"<request-id-value>"}` + "\n" +
+				`{"level":"error","msg":"request summary","request_id":"<request-id-value>","info_count":1,"error_count":1,"dpanic_count":1,"max_level":"dpanic"}` + "\n",
+		},
+		"Should write entries at a passthrough level below ErrorLevel immediately": {
+			opts: []Option{WithSummaryPassthrough(zapcore.WarnLevel)},
+			log: func(ctx context.Context, l Logger) {
+				l.Info(ctx, "info")
+				l.Warn(ctx, "warn")
+			},
+			expected: `{"level":"warn","msg":"warn","request_id":"<request-id-value>"}` + "\n" +
+				`{"level":"warn","msg":"request summary","request_id":"<request-id-value>","info_count":1,"warn_count":1,"max_level":"warn"}` + "\n",
+		},
+		"Should summarize a request without entries": {
+			log:      func(ctx context.Context, l Logger) {},
+			expected: `{"level":"info","msg":"request summary","request_id":"<request-id-value>"}` + "\n",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), tc.opts...)
+
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+			summaryCtx, finalize := l.SummaryContext(ctx)
+			tc.log(summaryCtx, l)
+			finalize()
+			finalize()
+
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
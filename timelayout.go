@@ -0,0 +1,44 @@
+package loggy
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// WithTimeValueLayout formats the time.Time field values with layout, as with
+// time.Time.Format, instead of the time encoder of the encoder config, which
+// keeps applying to the entry timestamp. The zero time is encoded as null.
+func WithTimeValueLayout(layout string) Option {
+	return func(o *options) {
+		o.timeValueLayout = layout
+	}
+}
+
+// formatTimes applies the time layout of the options to the fields holding a time.Time.
+func formatTimes(o *options, fields []zapcore.Field) []zapcore.Field {
+	if o.timeValueLayout == "" {
+		return fields
+	}
+	for i, f := range fields {
+		var t time.Time
+		switch f.Type {
+		case zapcore.TimeType:
+			t = time.Unix(0, f.Integer)
+			if loc, ok := f.Interface.(*time.Location); ok {
+				t = t.In(loc)
+			}
+		case zapcore.TimeFullType:
+			t = f.Interface.(time.Time)
+		default:
+			continue
+		}
+		if t.IsZero() {
+			fields[i] = zap.Reflect(f.Key, nil)
+			continue
+		}
+		fields[i] = zap.String(f.Key, t.Format(o.timeValueLayout))
+	}
+	return fields
+}
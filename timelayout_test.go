@@ -0,0 +1,51 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithTimeValueLayout(t *testing.T) {
+	tests := map[string]struct {
+		opts []Option
+	}{
+		"Should encode times with the default encoder": {},
+		"Should format times in RFC3339": {
+			opts: []Option{WithTimeValueLayout(time.RFC3339)},
+		},
+		"Should format times with a custom layout": {
+			opts: []Option{WithTimeValueLayout("02 Jan 2006 15:04")},
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), tc.opts...)
+
+			ctx, _ := l.With(context.Background(), "started_at", time.Date(2021, 7, 1, 9, 30, 0, 0, time.FixedZone("EDT", -4*60*60)))
+			l.Infow(ctx, "something goes here",
+				"expires_at", time.Date(2021, 7, 2, 0, 0, 0, 0, time.UTC),
+				zap.Time("deleted_at", time.Time{}),
+			)
+
+			if *updateGolden {
+				t.Log("Updating golden file:", goldenFilename(t))
+				require.NoError(t, os.MkdirAll(filepath.Dir(goldenFilename(t)), 0755))
+				require.NoError(t, os.WriteFile(goldenFilename(t), buf.Bytes(), 0644))
+			}
+
+			golden, err := os.ReadFile(goldenFilename(t))
+			require.NoError(t, err)
+			require.Equal(t, buf.Bytes(), golden)
+		})
+	}
+}
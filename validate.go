@@ -0,0 +1,37 @@
+package loggy
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// unapprovedMessage replaces the messages rejected by the message validator.
+const unapprovedMessage = "unapproved_message"
+
+// WithMessageValidator checks the message of every entry with validate. A message
+// it returns an error for is replaced with "unapproved_message" and moved to the
+// raw_msg field, and a warning carrying the error is logged, at most once per
+// second. This keeps the cardinality of messages bounded for metrics derived
+// from logs.
+func WithMessageValidator(validate func(msg string) error) Option {
+	return func(o *options) {
+		o.messageValidator = validate
+	}
+}
+
+// validateMessage applies the message validator of the options to ent.
+func (c *core) validateMessage(o *options, ent zapcore.Entry, fields []zapcore.Field) (zapcore.Entry, []zapcore.Field) {
+	if o.messageValidator == nil {
+		return ent, fields
+	}
+	err := o.messageValidator(ent.Message)
+	if err == nil {
+		return ent, fields
+	}
+	if allowWarning(&c.root.lastMessageWarning, validationWarningInterval) {
+		c.warn(ent, "loggy: unapproved log message", zap.String("raw_msg", ent.Message), zap.Error(err))
+	}
+	fields = append(fields, zap.String("raw_msg", ent.Message))
+	ent.Message = unapprovedMessage
+	return ent, fields
+}
@@ -0,0 +1,75 @@
+package loggy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithMessageValidator(t *testing.T) {
+	approved := map[string]bool{"request handled": true}
+	validate := func(msg string) error {
+		if !approved[msg] {
+			return errors.New("not a known message")
+		}
+		return nil
+	}
+
+	tests := map[string]struct {
+		msg      string
+		expected string
+	}{
+		"Should log an approved message as-is": {
+			msg:      "request handled",
+			expected: `{"level":"info","msg":"request handled","request_id":"<request-id-value>","key":"value"}` + "\n",
+		},
+		"Should replace an unapproved message": {
+			msg: "request 42 handled",
+			expected: `{"level":"warn","msg":"loggy: unapproved log message","raw_msg":"request 42 handled","error":"not a known message"}` + "\n" +
+				`{"level":"info","msg":"unapproved_message","request_id":"<request-id-value>","key":"value","raw_msg":"request 42 handled"}` + "\n",
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			buf := bytes.NewBuffer([]byte{})
+			l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithMessageValidator(validate))
+
+			ctx, _ := l.With(context.Background(), "request_id", "<request-id-value>")
+			l.Infow(ctx, tc.msg, "key", "value")
+
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestWithMessageValidator_ThrottlesWarnings(t *testing.T) {
+	start := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+	t.Cleanup(func() { now = time.Now })
+	now = func() time.Time { return start }
+
+	buf := bytes.NewBuffer([]byte{})
+	l := New(newZapTestLogger(t, zapcore.AddSync(buf)).Sugar(), WithMessageValidator(func(msg string) error {
+		return errors.New("not a known message")
+	}))
+
+	ctx, _ := l.With(context.Background())
+	l.Info(ctx, "first")
+	l.Info(ctx, "second")
+	now = func() time.Time { return start.Add(time.Second) }
+	l.Info(ctx, "third")
+
+	require.Equal(t,
+		`{"level":"warn","msg":"loggy: unapproved log message","raw_msg":"first","error":"not a known message"}`+"\n"+
+			`{"level":"info","msg":"unapproved_message","raw_msg":"first"}`+"\n"+
+			`{"level":"info","msg":"unapproved_message","raw_msg":"second"}`+"\n"+
+			`{"level":"warn","msg":"loggy: unapproved log message","raw_msg":"third","error":"not a known message"}`+"\n"+
+			`{"level":"info","msg":"unapproved_message","raw_msg":"third"}`+"\n",
+		buf.String(),
+	)
+}
@@ -0,0 +1,44 @@
+//go:build go1.21
+// +build go1.21
+
+package loggy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// WarnOnDeadline logs a single "approaching deadline" warning, with the fields of
+// the logger carried by ctx and the time left in the deadline_remaining field,
+// once less than threshold is left before the deadline of ctx. Nothing is logged
+// if ctx is done earlier or has no deadline. It starts no goroutine: a timer fires
+// the warning and is stopped as soon as ctx is done. It is only available from
+// Go 1.21.
+func (l Logger) WarnOnDeadline(ctx context.Context, threshold time.Duration) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return
+	}
+	var (
+		mu   sync.Mutex
+		stop func() bool
+	)
+	// Hold mu until stop is set, in case the timer fires right away.
+	mu.Lock()
+	defer mu.Unlock()
+	stopTimer := afterFunc(deadline.Add(-threshold).Sub(now()), func() {
+		mu.Lock()
+		stop()
+		mu.Unlock()
+		if ctx.Err() != nil {
+			return
+		}
+		if s, ok := l.sugarAt(ctx, zapcore.WarnLevel); ok {
+			s.Warnw("approaching deadline", "deadline_remaining", deadline.Sub(now()))
+		}
+	})
+	stop = context.AfterFunc(ctx, func() { stopTimer() })
+}
@@ -0,0 +1,80 @@
+//go:build go1.21
+// +build go1.21
+
+package loggy
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger_WarnOnDeadline(t *testing.T) {
+	tests := map[string]struct {
+		finish   bool
+		expected []string
+	}{
+		"Should warn once for a long running context": {
+			expected: []string{`{"level":"warn","msg":"approaching deadline","request_id":"<request-id-value>","deadline_remaining":"10m0s"}`},
+		},
+		"Should not warn for a context done early": {
+			finish: true,
+		},
+	}
+	for name, tc := range tests {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var (
+				delay time.Duration
+				fire  func()
+			)
+			stopped := make(chan struct{})
+			realAfterFunc := afterFunc
+			t.Cleanup(func() { afterFunc = realAfterFunc })
+			afterFunc = func(d time.Duration, f func()) func() bool {
+				delay, fire = d, f
+				return func() bool {
+					close(stopped)
+					return true
+				}
+			}
+			deadline := time.Now().Add(time.Hour)
+			t.Cleanup(func() { now = time.Now })
+			now = func() time.Time { return deadline.Add(-time.Hour) }
+
+			buf := &lockedBuffer{}
+			l := New(newZapTestLogger(t, buf).Sugar())
+			ctx, cancel := context.WithDeadline(context.Background(), deadline)
+			defer cancel()
+			ctx, _ = l.With(ctx, "request_id", "<request-id-value>")
+			l.WarnOnDeadline(ctx, 10*time.Minute)
+			require.Equal(t, 50*time.Minute, delay)
+
+			if tc.finish {
+				cancel()
+				<-stopped
+			}
+			now = func() time.Time { return deadline.Add(-10 * time.Minute) }
+			fire()
+
+			var lines []string
+			for _, line := range strings.Split(buf.String(), "\n") {
+				if line != "" {
+					lines = append(lines, line)
+				}
+			}
+			require.Equal(t, tc.expected, lines)
+		})
+	}
+}
+
+func TestLogger_WarnOnDeadline_NoDeadline(t *testing.T) {
+	buf := &lockedBuffer{}
+	l := New(newZapTestLogger(t, buf).Sugar())
+
+	l.WarnOnDeadline(context.Background(), time.Second)
+	require.Empty(t, buf.String())
+}